@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/GarrickZ2/archie/internal/agent"
+	"github.com/GarrickZ2/archie/internal/ui"
+)
+
+var (
+	agentRemoveYesFlag   bool
+	agentRemoveForceFlag bool
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Manage the agents installed in this project",
+	Long: `Manage the agents installed in this project.
+
+Every file an agent installs is recorded with its content hash in
+.archie/state.json, so it can be removed cleanly later.`,
+}
+
+var agentRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Uninstall an agent from this project",
+	Long: `Delete exactly the files recorded for an agent in .archie/state.json,
+prune the directories left empty, and forget the agent.
+
+Files also installed by another agent (e.g. AGENTS.md shared by cursor and
+windsurf) are kept. From a background doc such as CLAUDE.md only the Archie
+block is removed; the file is deleted when nothing else is left in it.
+
+Files you edited since they were installed are kept unless --force is given.
+The <file>.new copies written by archie sync are always deleted.
+
+Examples:
+  archie agent remove cursor
+  archie agent remove cursor --yes
+  archie agent remove cursor --force   # also delete files you edited`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runAgentRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+	agentCmd.AddCommand(agentRemoveCmd)
+
+	agentRemoveCmd.Flags().BoolVarP(&agentRemoveYesFlag, "yes", "y", false, "Do not ask for confirmation")
+	agentRemoveCmd.Flags().BoolVar(&agentRemoveForceFlag, "force", false, "Also delete files you edited since they were installed")
+}
+
+func runAgentRemove(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	agentName := args[0]
+
+	if !agentRemoveYesFlag {
+		var confirm bool
+		prompt := &survey.Confirm{
+			Message: fmt.Sprintf("Remove agent '%s' and delete the files it installed?", agentName),
+			Default: false,
+		}
+		if err := survey.AskOne(prompt, &confirm); err != nil {
+			return err
+		}
+		if !confirm {
+			return nil
+		}
+		fmt.Println()
+	}
+
+	// Custom agents are needed for installs recorded without a manifest (non-fatal if it fails)
+	_ = agent.LoadAndRegister(projectPath)
+
+	syncer := agent.NewSyncer(nil)
+	syncer.SetForce(agentRemoveForceFlag)
+	removed, err := syncer.Remove(projectPath, agentName)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to remove agent: %v", err))
+		return err
+	}
+
+	showFileResults(removed)
+	if len(removed) > 0 {
+		fmt.Println()
+	}
+	deleted, kept := 0, 0
+	for _, file := range removed {
+		switch file.Change {
+		case agent.FileRemoved:
+			deleted++
+		case agent.FileKept:
+			kept++
+		}
+	}
+	ui.ShowSuccess(fmt.Sprintf("Removed agent '%s' (%d file(s) deleted)", agentName, deleted))
+	if kept > 0 {
+		ui.ShowWarning(fmt.Sprintf("Kept %d file(s) you edited; delete them by hand or use --force", kept))
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/ui"
+)
+
+var (
+	blockerDescriptionFlag string
+	blockerOwnerFlag       string
+	blockerByFlag          string
+	blockerAllFlag         bool
+	blockerFeatureFlag     string
+	blockerFormatFlag      string
+)
+
+var blockerCmd = &cobra.Command{
+	Use:   "blocker",
+	Short: "Track blockers in blocker.md",
+	Long: `Track blockers in blocker.md without an agent session.
+
+Adding a blocker moves the feature to BLOCKED; resolving the last open
+blocker of a feature restores the status it had before. Both update the
+feature's ## Changelog.`,
+}
+
+var blockerAddCmd = &cobra.Command{
+	Use:   "add <feature-key> <title>",
+	Short: "Open a blocker and move the feature to BLOCKED",
+	Long: `Append a blocker to blocker.md and move the feature to BLOCKED.
+
+The status the feature had before is recorded in its ## Changelog and is
+restored when the blocker is resolved. If the feature is already BLOCKED,
+only a Changelog line is added.
+
+Examples:
+  archie blocker add checkout-discount "Legal review of discount terms"
+  archie blocker add checkout-discount "Missing SSO metadata" -d "IdP has not shared metadata" --owner alice`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runBlockerAdd,
+}
+
+var blockerResolveCmd = &cobra.Command{
+	Use:   "resolve <blocker-id>",
+	Short: "Resolve a blocker and restore the feature status",
+	Long: `Mark a blocker as resolved in blocker.md.
+
+When the feature has no other open blockers, its status is restored to the
+status it had before it was blocked.
+
+Examples:
+  archie blocker resolve B-3`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runBlockerResolve,
+}
+
+var blockerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List blockers with their age",
+	Long: `List the open blockers in blocker.md, oldest first.
+
+Examples:
+  archie blocker list
+  archie blocker list --all --feature checkout-discount
+  archie blocker list --format json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runBlockerList,
+}
+
+func init() {
+	rootCmd.AddCommand(blockerCmd)
+	blockerCmd.AddCommand(blockerAddCmd, blockerResolveCmd, blockerListCmd)
+
+	blockerAddCmd.Flags().StringVarP(&blockerDescriptionFlag, "description", "d", "", "What blocks the feature and what is needed to unblock it")
+	blockerAddCmd.Flags().StringVar(&blockerOwnerFlag, "owner", "", "Who drives the blocker to resolution")
+	blockerAddCmd.Flags().StringVar(&blockerByFlag, "by", "", "Author recorded in the Changelog (default: git user.name or $USER)")
+
+	blockerResolveCmd.Flags().StringVar(&blockerByFlag, "by", "", "Author recorded in the Changelog (default: git user.name or $USER)")
+
+	blockerListCmd.Flags().BoolVarP(&blockerAllFlag, "all", "a", false, "Include resolved blockers")
+	blockerListCmd.Flags().StringVarP(&blockerFeatureFlag, "feature", "f", "", "Only show blockers of this feature")
+	blockerListCmd.Flags().StringVar(&blockerFormatFlag, "format", "text", "Output format: text, json or yaml")
+}
+
+func runBlockerAdd(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	author := blockerByFlag
+	if author == "" {
+		author = currentAuthor()
+	}
+
+	featureKey := extractFeatureKey(args[0])
+	result, err := status.NewBlockerManager(nil).Add(projectPath, status.NewBlocker{
+		Feature:     featureKey,
+		Title:       args[1],
+		Description: blockerDescriptionFlag,
+		Owner:       blockerOwnerFlag,
+		Author:      author,
+	})
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to add blocker: %v", err))
+		return err
+	}
+
+	ui.ShowSuccess(fmt.Sprintf("%s opened for %s", result.Blocker.ID, featureKey))
+	showBlockerResult(result)
+	return nil
+}
+
+func runBlockerResolve(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	author := blockerByFlag
+	if author == "" {
+		author = currentAuthor()
+	}
+
+	result, err := status.NewBlockerManager(nil).Resolve(projectPath, args[0], author, time.Time{})
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to resolve blocker: %v", err))
+		return err
+	}
+
+	ui.ShowSuccess(fmt.Sprintf("%s resolved", result.Blocker.ID))
+	showBlockerResult(result)
+
+	if len(result.StillBlockedBy) > 0 {
+		ui.ShowInfo(fmt.Sprintf("%s is still blocked by %s", result.Blocker.Feature, strings.Join(result.StillBlockedBy, ", ")))
+		fmt.Println()
+	}
+	return nil
+}
+
+// showBlockerResult prints the status change or Changelog line written for a blocker
+func showBlockerResult(result *status.BlockerResult) {
+	if result.StatusChange != nil {
+		fmt.Printf("  Status:    %s → %s\n", result.StatusChange.From, result.StatusChange.To)
+		fmt.Printf("  Changelog: %s\n", result.StatusChange.ChangelogEntry)
+	} else if result.ChangelogEntry != "" {
+		fmt.Printf("  Changelog: %s\n", result.ChangelogEntry)
+	}
+	fmt.Println()
+}
+
+func runBlockerList(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	format, err := status.ParseOutputFormat(blockerFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	blockers, err := status.NewBlockerParser(nil).ParseProject(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to parse blockers: %v", err))
+		return err
+	}
+
+	if !blockerAllFlag {
+		blockers = status.OpenBlockers(blockers)
+	}
+
+	selected := []status.Blocker{}
+	featureKey := extractFeatureKey(blockerFeatureFlag)
+	for _, blocker := range blockers {
+		if featureKey == "" || blocker.Feature == featureKey {
+			selected = append(selected, blocker)
+		}
+	}
+
+	if format != status.FormatText {
+		return status.WriteFormatted(cmd.OutOrStdout(), format, selected)
+	}
+
+	if len(selected) == 0 {
+		ui.ShowInfo("No blockers found")
+		fmt.Println()
+		return nil
+	}
+
+	now := time.Now()
+	fmt.Println()
+	fmt.Printf("  %-6s %-24s %-12s %-8s %-10s %s\n", "ID", "Feature", "Owner", "Age", "Resolved", "Title")
+	fmt.Println("  " + ui.ColorDim + strings.Repeat("─", 76) + ui.ColorReset)
+	for _, blocker := range selected {
+		owner := blocker.Owner
+		if owner == "" {
+			owner = "-"
+		}
+		resolved := blocker.Resolved
+		if resolved == "" {
+			resolved = "-"
+		}
+		age := "-"
+		if days := blocker.AgeDays(now); days >= 0 {
+			age = fmt.Sprintf("%dd", days)
+		}
+		fmt.Printf("  %-6s %-24s %-12s %-8s %-10s %s\n", blocker.ID, blocker.Feature, owner, age, resolved, blocker.Title)
+	}
+	fmt.Println()
+
+	return nil
+}
@@ -2,20 +2,20 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/GarrickZ2/archie/internal/agent"
 	"github.com/GarrickZ2/archie/internal/clone"
+	"github.com/GarrickZ2/archie/internal/config"
 	"github.com/GarrickZ2/archie/internal/ui"
 )
 
 var cloneCmd = &cobra.Command{
 	Use:   "clone <source-path>",
 	Short: "Clone an existing Archie project",
-	Long: `Clone an existing Archie project to the current directory.
+	Long: `Clone an existing Archie project to the current directory (or the -C directory).
 
 This command will:
 1. Validate source project (must have .archie folder)
@@ -38,6 +38,9 @@ Clone Strategies:
   custom   - Manually select which files and directories to copy
              You can choose from all standard project items
 
+Strategies can be overridden or added under clone.strategies in
+.archie/config.yaml or ~/.archie/config.yaml.
+
 Examples:
   # Clone from a local project
   archie clone /path/to/source-project
@@ -53,8 +56,9 @@ func init() {
 }
 
 func runClone(cmd *cobra.Command, args []string) error {
-	// Load custom agents (non-fatal if fails)
-	if err := agent.LoadAndRegister(); err != nil {
+	// Load the user's custom agents (non-fatal if fails); the source project's
+	// own agents are copied and registered during replication
+	if err := agent.LoadAndRegister(""); err != nil {
 		// Just log info, continue with built-in agents
 		ui.ShowInfo("⚠️  Could not load custom agents: " + err.Error())
 		fmt.Println()
@@ -70,23 +74,30 @@ func runClone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid source path: %w", err)
 	}
 
-	// Get target path (current directory)
-	targetPath, err := os.Getwd()
+	// Get target path (-C directory or current directory)
+	targetPath, err := startDir()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to get current directory: %v", err))
-		return fmt.Errorf("failed to get current directory: %w", err)
+		ui.ShowError(err.Error())
+		return err
+	}
+
+	cfg, err := config.Load(targetPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		return err
 	}
 
 	// Create clone manager
 	manager := clone.NewCloneManager(nil)
+	manager.SetStrategies(cloneStrategies(cfg))
 
 	// Execute clone
-	config := clone.CloneConfig{
+	cloneConfig := clone.CloneConfig{
 		SourcePath: absSourcePath,
 		TargetPath: targetPath,
 	}
 
-	_, err = manager.Clone(config)
+	_, err = manager.Clone(cloneConfig)
 	if err != nil {
 		ui.ShowError(fmt.Sprintf("Clone failed: %v", err))
 		return err
@@ -94,3 +105,17 @@ func runClone(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// cloneStrategies converts clone.strategies from config into clone definitions
+func cloneStrategies(cfg *config.Config) map[clone.CloneStrategy]clone.StrategyDefinition {
+	defs := make(map[clone.CloneStrategy]clone.StrategyDefinition, len(cfg.Clone.Strategies))
+	for name, strategy := range cfg.Clone.Strategies {
+		defs[clone.CloneStrategy(name)] = clone.StrategyDefinition{
+			Name:        clone.CloneStrategy(name),
+			Description: strategy.Description,
+			Files:       strategy.Files,
+			Directories: strategy.Directories,
+		}
+	}
+	return defs
+}
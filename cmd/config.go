@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GarrickZ2/archie/internal/config"
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/ui"
+)
+
+var (
+	configGlobalFlag bool
+	configFormatFlag string
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and write Archie settings",
+	Long: `Read and write Archie settings.
+
+Settings are layered: built-in defaults, then ~/.archie/config.yaml (user),
+then .archie/config.yaml in the project. A project setting overrides the
+same key in the user file; maps such as status.stale_days are merged key
+by key.
+
+Available settings:
+  export.path_format          Default export path ({{date}} and {{project}} are expanded)
+  status.stale_days.<STATUS>  Days a feature may stay in a status ("default" for the rest)
+  status.wip_limits.status.<STATUS>
+  status.wip_limits.owner.<owner>  ("*" applies to every owner)
+  feature.dependency_policy   warn or refuse
+  agent.default               Agent preselected by archie init
+  editor                      Editor used when $EDITOR is not set
+  clone.strategies.<name>     description, files and directories of a clone strategy`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List effective settings and where they come from",
+	Long: `List every effective setting as a dotted key, with the layer
+(default, user or project) that sets it.
+
+Examples:
+  archie config list
+  archie config list --format json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runConfigList,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a setting",
+	Long: `Print the effective value of a setting. Sections are printed as YAML.
+
+Examples:
+  archie config get export.path_format
+  archie config get status.stale_days`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Write a setting to the project or user config",
+	Long: `Write a setting to .archie/config.yaml, or to ~/.archie/config.yaml
+with --global. Comments and other settings in the file are kept.
+
+The value is parsed as YAML, so numbers and lists can be given directly.
+The whole configuration is validated before the file is written.
+
+Examples:
+  archie config set status.stale_days.UNDER_REVIEW 5
+  archie config set export.path_format "./exports/{{project}}-{{date}}.md"
+  archie config set --global editor code
+  archie config set clone.strategies.api.files "[background.md, dependency.md]"`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runConfigSet,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configListCmd, configGetCmd, configSetCmd)
+
+	configListCmd.Flags().StringVar(&configFormatFlag, "format", "text", "Output format: text, json or yaml")
+	configSetCmd.Flags().BoolVarP(&configGlobalFlag, "global", "g", false, "Write to ~/.archie/config.yaml instead of the project")
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	format, err := status.ParseOutputFormat(configFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	entries, err := config.NewLoader(nil).List(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		return err
+	}
+
+	if format != status.FormatText {
+		return status.WriteFormatted(cmd.OutOrStdout(), format, entries)
+	}
+
+	width := 0
+	for _, entry := range entries {
+		if len(entry.Key) > width {
+			width = len(entry.Key)
+		}
+	}
+
+	fmt.Println()
+	for _, entry := range entries {
+		value := entry.Value
+		if value == "" {
+			value = `""`
+		}
+		fmt.Printf("  %-*s = %-30s %s(%s)%s\n", width, entry.Key, value, ui.ColorDim, entry.Origin, ui.ColorReset)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	value, _, err := config.NewLoader(nil).Get(projectPath, args[0])
+	if err != nil {
+		ui.ShowError(err.Error())
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), value)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	layer := config.LayerProject
+	if configGlobalFlag {
+		layer = config.LayerUser
+	}
+
+	path, err := config.NewLoader(nil).Set(layer, projectPath, args[0], args[1])
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to set %s: %v", args[0], err))
+		return err
+	}
+
+	ui.ShowSuccess(fmt.Sprintf("%s = %s", args[0], strings.TrimSpace(args[1])))
+	fmt.Printf("  Written to %s\n\n", path)
+	return nil
+}
@@ -1,32 +1,220 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/GarrickZ2/archie/internal/agent"
+	"github.com/GarrickZ2/archie/internal/project"
+	"github.com/GarrickZ2/archie/internal/ui"
+)
+
+var (
+	customAgentFromFileFlag string
+	customAgentProjectFlag  bool
+	customAgentExportFlag   []string
 )
 
 var customAgentCmd = &cobra.Command{
 	Use:   "custom-agent",
 	Short: "Manage custom agents",
-	Long: `Manage custom agents stored in ~/.archie/custom_agents.json.
+	Long: `Manage custom agents stored in the project or in your home directory.
 
 This command provides a TUI interface to:
 1. Add new custom agents
-2. Remove existing custom agents
-3. List all custom agents
+2. Edit existing custom agents
+3. Remove existing custom agents
+4. List all custom agents
+
+Custom agents are stored in one of two places, chosen when an agent is added:
+  .archie/agents.json            Project store, checked into the repo so every
+                                 teammate (and archie clone) gets the agent
+  ~/.archie/custom_agents.json   User store, reused across all your projects
+
+When both define an agent with the same name, the project store wins over the
+user store. The names of the built-in agents (claude-code, cursor, ...) are
+reserved and cannot be used for custom agents.
+
+With --from-file the agents in a JSON file are registered without the TUI
+(same as 'archie custom-agent import').
+
+Examples:
+  archie custom-agent
+  archie custom-agent --from-file ./my-agent.json --project
+  archie custom-agent export team-agents.json
+  archie custom-agent import team-agents.json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runCustomAgent,
+}
+
+var customAgentExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write custom agent definitions to a JSON file",
+	Long: `Write the custom agents from the project and user stores to a JSON file
+("-" writes to stdout). The file can be shared and loaded with
+'archie custom-agent import'.
 
-Custom agents are stored globally in your home directory,
-so they can be reused across all projects.`,
-	Args: cobra.NoArgs,
-	RunE: runCustomAgent,
+Examples:
+  archie custom-agent export team-agents.json
+  archie custom-agent export - --agent my-agent`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runCustomAgentExport,
+}
+
+var customAgentImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Register custom agents from a JSON file",
+	Long: `Register the custom agents defined in a JSON file: one agent object, or
+an array as written by 'archie custom-agent export'. Every agent is validated
+before anything is saved. Agents with the same name are replaced.
+
+Agents are saved to the user store, or to .archie/agents.json with --project.
+
+Examples:
+  archie custom-agent import team-agents.json
+  archie custom-agent import my-agent.json --project`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runCustomAgentImport,
 }
 
 func init() {
 	rootCmd.AddCommand(customAgentCmd)
+	customAgentCmd.AddCommand(customAgentExportCmd, customAgentImportCmd)
+
+	customAgentCmd.Flags().StringVar(&customAgentFromFileFlag, "from-file", "", "Register the custom agents in this JSON file without the TUI")
+	customAgentCmd.Flags().BoolVar(&customAgentProjectFlag, "project", false, "Save to .archie/agents.json instead of the user store")
+	customAgentImportCmd.Flags().BoolVar(&customAgentProjectFlag, "project", false, "Save to .archie/agents.json instead of the user store")
+	customAgentExportCmd.Flags().StringSliceVar(&customAgentExportFlag, "agent", nil, "Only export these agents, comma separated (default: all)")
 }
 
 func runCustomAgent(cmd *cobra.Command, args []string) error {
-	manager := agent.NewCustomAgentManager()
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	if customAgentFromFileFlag != "" {
+		return importCustomAgents(projectPath, customAgentFromFileFlag)
+	}
+
+	manager := agent.NewCustomAgentManager(projectPath)
 	return manager.ShowManagementUI()
 }
+
+func runCustomAgentImport(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	return importCustomAgents(projectPath, args[0])
+}
+
+func runCustomAgentExport(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	configs, err := agent.LoadCustomAgents(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load custom agents: %v", err))
+		return err
+	}
+
+	if len(customAgentExportFlag) > 0 {
+		selected := make([]agent.CustomAgentConfig, 0, len(customAgentExportFlag))
+		for _, name := range customAgentExportFlag {
+			found := false
+			for _, config := range configs {
+				if config.Name == name {
+					selected = append(selected, config)
+					found = true
+					break
+				}
+			}
+			if !found {
+				err := fmt.Errorf("custom agent '%s' not found", name)
+				ui.ShowError(err.Error())
+				return err
+			}
+		}
+		configs = selected
+	}
+
+	data, err := json.MarshalIndent(configs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal custom agents: %w", err)
+	}
+	data = append(data, '\n')
+
+	if args[0] == "-" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(args[0], data, 0644); err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to write %s: %v", args[0], err))
+		return err
+	}
+
+	ui.ShowSuccess(fmt.Sprintf("Exported %d custom agent(s) to %s", len(configs), args[0]))
+	fmt.Println()
+	return nil
+}
+
+// importCustomAgents validates the agents in a JSON file and saves them to the
+// user store, or to the project store with --project
+func importCustomAgents(projectPath, path string) error {
+	// Outside a project the store would create .archie here and turn the directory into one
+	if customAgentProjectFlag {
+		if _, err := project.NewRootFinder(nil).Find(projectPath); err != nil {
+			err = fmt.Errorf("--project needs an Archie project: %w", err)
+			ui.ShowError(err.Error())
+			return err
+		}
+	}
+
+	configs, err := agent.LoadCustomAgentsFile(nil, path)
+	if err != nil {
+		ui.ShowError(err.Error())
+		return err
+	}
+
+	store := agent.NewCustomAgentStore(nil)
+	if customAgentProjectFlag {
+		store = agent.NewProjectAgentStore(nil, projectPath)
+	}
+
+	// Every agent in the file was validated (including reserved names) before any is saved
+	for _, config := range configs {
+		if err := saveCustomAgent(store, config); err != nil {
+			ui.ShowError(err.Error())
+			return err
+		}
+		ui.ShowSuccess(fmt.Sprintf("Registered custom agent '%s' (%s store)", config.Name, store.Scope()))
+	}
+	fmt.Println()
+	return nil
+}
+
+// saveCustomAgent validates a custom agent, then saves it to a store and registers it
+func saveCustomAgent(store *agent.CustomAgentStore, config agent.CustomAgentConfig) error {
+	if err := agent.ValidateCustomAgentConfig(config); err != nil {
+		return err
+	}
+
+	if err := store.Put(config); err != nil {
+		return fmt.Errorf("failed to save custom agent: %w", err)
+	}
+	store.RegisterAgent(config)
+	return nil
+}
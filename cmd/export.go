@@ -2,19 +2,21 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/GarrickZ2/archie/internal/config"
 	"github.com/GarrickZ2/archie/internal/export"
 	"github.com/GarrickZ2/archie/internal/ui"
 )
 
 var (
-	outputPath string
-	noTOC      bool
-	noStats    bool
-	noDepGraph bool
+	outputPath   string
+	noTOC        bool
+	noStats      bool
+	noDepGraph   bool
+	withTimeline bool
 )
 
 var exportCmd = &cobra.Command{
@@ -35,25 +37,33 @@ Options can be customized through flags or interactive prompts.`,
 
 func init() {
 	rootCmd.AddCommand(exportCmd)
-	exportCmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (default: ./archie-export-YYYY-MM-DD.md)")
+	exportCmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (default: export.path_format from config, ./archie-export-YYYY-MM-DD.md)")
 	exportCmd.Flags().BoolVar(&noTOC, "no-toc", false, "Skip table of contents generation")
 	exportCmd.Flags().BoolVar(&noStats, "no-stats", false, "Skip status statistics")
 	exportCmd.Flags().BoolVar(&noDepGraph, "no-dep-graph", false, "Skip dependency graph")
+	exportCmd.Flags().BoolVar(&withTimeline, "timeline", false, "Include the timeline (Mermaid gantt) from tasks.md")
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
-	// Get current directory as project path
-	projectPath, err := os.Getwd()
+	projectPath, err := projectRoot()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to get current directory: %v", err))
-		return fmt.Errorf("failed to get current directory: %w", err)
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		return err
 	}
 
 	// Create export manager
 	manager := export.NewExportManager(projectPath, nil)
+	manager.SetDefaultOutputPath(cfg.ExportPath(projectPath, time.Now()))
 
 	// Set flags from command line
 	manager.SetFlags(outputPath, !noTOC, !noStats, !noDepGraph)
+	manager.SetTimeline(withTimeline)
 
 	// Execute export
 	result, err := manager.Export()
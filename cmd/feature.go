@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GarrickZ2/archie/internal/config"
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/ui"
+)
+
+var (
+	statusReasonFlag     string
+	statusByFlag         string
+	dependencyPolicyFlag string
+)
+
+var featureCmd = &cobra.Command{
+	Use:   "feature",
+	Short: "Manage features from the command line",
+	Long: `Manage features from the command line.
+
+Use the subcommands to make validated changes to features/<feature-key>.md
+instead of editing fields by hand.`,
+}
+
+var featureSetStatusCmd = &cobra.Command{
+	Use:   "set-status <feature-key> <status>",
+	Short: "Change a feature's status along an allowed transition",
+	Long: `Change a feature's status, enforcing the feature state machine.
+
+Allowed transitions:
+  NOT_REVIEWED     → UNDER_REVIEW
+  UNDER_REVIEW     → READY_FOR_DESIGN
+  READY_FOR_DESIGN → UNDER_DESIGN, DESIGNED
+  UNDER_DESIGN     → DESIGNED
+  DESIGNED         → SPEC_READY, IMPLEMENTING, UNDER_DESIGN
+  SPEC_READY       → IMPLEMENTING
+  IMPLEMENTING     → FINISHED
+
+  Any state except FINISHED → BLOCKED (requires --reason)
+  BLOCKED → the status the feature had before it was blocked
+
+The command updates "- Value:", "- Last Updated:" and "- Reason:" in the
+## Status section and appends an entry to ## Changelog.
+
+Dependency readiness:
+  Moving to UNDER_DESIGN or DESIGNED requires every feature listed in
+  ## Feature Dependencies to be at least READY_FOR_DESIGN; SPEC_READY,
+  IMPLEMENTING and FINISHED require them to be at least DESIGNED.
+  BLOCKED dependencies and missing feature keys never count as ready.
+  --dependency-policy warn (default) prints a warning, refuse rejects the change.
+  The default can be changed with feature.dependency_policy in .archie/config.yaml.
+
+Examples:
+  archie feature set-status checkout-discount UNDER_REVIEW
+  archie feature set-status checkout-discount blocked --reason "waiting on legal"
+  archie feature set-status features/checkout-discount.md under-design --by alice
+  archie feature set-status checkout-discount SPEC_READY --dependency-policy refuse`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runFeatureSetStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(featureCmd)
+	featureCmd.AddCommand(featureSetStatusCmd)
+	featureSetStatusCmd.Flags().StringVarP(&statusReasonFlag, "reason", "r", "", "Reason for the status (required for BLOCKED)")
+	featureSetStatusCmd.Flags().StringVar(&statusByFlag, "by", "", "Author recorded in the Changelog (default: git user.name or $USER)")
+	featureSetStatusCmd.Flags().StringVar(&dependencyPolicyFlag, "dependency-policy", "", "What to do when dependencies are not ready: warn or refuse (default: feature.dependency_policy from config, else warn)")
+}
+
+func runFeatureSetStatus(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	featureKey := extractFeatureKey(args[0])
+	target := status.ParseStatusName(args[1])
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		return err
+	}
+
+	// The flag overrides feature.dependency_policy from the config
+	policyName := cfg.Feature.DependencyPolicy
+	if cmd.Flags().Changed("dependency-policy") {
+		policyName = dependencyPolicyFlag
+	}
+	policy, err := status.ParseReadinessPolicy(policyName)
+	if err != nil {
+		return err
+	}
+
+	author := statusByFlag
+	if author == "" {
+		author = currentAuthor()
+	}
+
+	updater := status.NewStatusUpdater(nil)
+	result, err := updater.SetStatus(projectPath, featureKey, status.StatusChange{
+		To:     target,
+		Reason: statusReasonFlag,
+		Author: author,
+		Policy: policy,
+	})
+	if err != nil {
+		var transitionErr *status.TransitionError
+		var readinessErr *status.ReadinessError
+		if errors.As(err, &transitionErr) || errors.As(err, &readinessErr) {
+			ui.ShowError(err.Error())
+			return err
+		}
+		ui.ShowError(fmt.Sprintf("Failed to update status: %v", err))
+		return err
+	}
+
+	ui.ShowSuccess(fmt.Sprintf("%s: %s → %s", result.FeatureKey, result.From, result.To))
+	fmt.Printf("  Changelog: %s\n", result.ChangelogEntry)
+
+	if result.Readiness != nil && !result.Readiness.Ready {
+		fmt.Println()
+		ui.ShowWarning(fmt.Sprintf("Dependencies are not %s yet: %s",
+			result.Readiness.Required, result.Readiness.Describe()))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// currentAuthor returns the git user name, falling back to $USER
+func currentAuthor() string {
+	out, err := exec.Command("git", "config", "user.name").Output()
+	if err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
+		}
+	}
+	return os.Getenv("USER")
+}
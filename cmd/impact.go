@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/ui"
+)
+
+var impactFormatFlag string
+
+var impactCmd = &cobra.Command{
+	Use:   "impact <feature-key>",
+	Short: "List every feature affected by a change to a feature",
+	Long: `Walk the dependency graph in reverse and list every feature that depends on
+the given feature, directly or transitively.
+
+Each downstream feature is shown with its depth (1 = direct dependent),
+its current status and the dependency chain that connects it.
+
+Dependents that are already past DESIGNED (SPEC_READY, IMPLEMENTING,
+FINISHED) are flagged: changing the upstream design may invalidate their
+specs and test plans, so they should be re-reviewed before running /revise.
+
+An unknown feature exits with a non-zero status in every format.
+
+Examples:
+  archie impact user-auth
+  archie impact features/user-auth.md --format json`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runImpact,
+}
+
+func init() {
+	rootCmd.AddCommand(impactCmd)
+	impactCmd.Flags().StringVar(&impactFormatFlag, "format", "text", "Output format: text, json or yaml")
+}
+
+func runImpact(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	format, err := status.ParseOutputFormat(impactFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	features, err := status.NewParser(nil).ParseFeaturesDir(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to parse features: %v", err))
+		return fmt.Errorf("failed to parse features: %w", err)
+	}
+
+	featureKey := extractFeatureKey(args[0])
+	graph := status.BuildDependencyGraph(features)
+	report, err := graph.Impact(featureKey)
+	if err != nil {
+		// An unknown feature fails in every format so scripts and CI can rely on the exit code
+		if format == status.FormatText {
+			if listErr := handleFeatureNotFound(projectPath, featureKey); listErr != nil {
+				return listErr
+			}
+		}
+		return err
+	}
+
+	if format != status.FormatText {
+		return status.WriteFormatted(cmd.OutOrStdout(), format, report)
+	}
+
+	status.NewImpactDisplay(report).Show()
+	return nil
+}
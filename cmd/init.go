@@ -3,33 +3,70 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/GarrickZ2/archie/internal/agent"
+	"github.com/GarrickZ2/archie/internal/config"
 	"github.com/GarrickZ2/archie/internal/project"
 	"github.com/GarrickZ2/archie/internal/ui"
+	"github.com/GarrickZ2/archie/internal/version"
+)
+
+var (
+	initAgentFlag           []string
+	initYesFlag             bool
+	initCustomAgentFileFlag string
+	initEnabledCommandsFlag []string
+	initForceFlag           bool
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new technical design documentation project",
-	Long: `Initialize a new technical design documentation project in the current directory.
+	Long: `Initialize a new technical design documentation project in the current directory
+(or the directory given with -C).
 
 This command will:
 1. Bootstrap the project structure
-2. Let you select and configure a Code Agent (claude-code or custom)
+2. Let you select and configure one or more Code Agents (claude-code, cursor, custom, ...)
 3. Generate agent-specific commands and sub-agents
 
-If the directory is not empty, you'll be prompted for confirmation.`,
-	Args: cobra.NoArgs,
-	RunE: runInit,
+The agent prompts can be skipped for CI and scripted bootstrap:
+  --agent              Agents to set up instead of selecting one in the TUI
+  --yes                Never prompt: re-configure initialized agents and fall back
+                       to agent.default from the config when --agent is not given
+  --custom-agent-file  JSON file with a custom agent (same fields as
+                       ~/.archie/custom_agents.json); it is saved and set up
+  --enabled-commands   Only install these commands (e.g. design,spec)
+
+Re-configuring an agent never silently replaces installed files you have
+edited: you are asked to keep, overwrite, merge or write a .new copy (with
+--yes a .new copy is written). --force overwrites them.
+
+Examples:
+  # Interactive
+  archie init
+
+  # Scripted
+  archie init --agent claude-code,cursor --yes
+  archie init --custom-agent-file ./my-agent.json --enabled-commands design,spec --yes`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runInit,
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().StringSliceVar(&initAgentFlag, "agent", nil, "Agents to set up, comma separated (skips the agent selection prompt)")
+	initCmd.Flags().BoolVarP(&initYesFlag, "yes", "y", false, "Answer yes to all prompts")
+	initCmd.Flags().StringVar(&initCustomAgentFileFlag, "custom-agent-file", "", "Register and set up the custom agent defined in this JSON file")
+	initCmd.Flags().StringSliceVar(&initEnabledCommandsFlag, "enabled-commands", nil, "Only install these commands, comma separated (default: all)")
+	initCmd.Flags().BoolVar(&initForceFlag, "force", false, "Overwrite installed files you have edited")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -38,11 +75,17 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// Show welcome banner with ARCHIE logo
 	ui.ShowWelcomeBanner()
 
-	// Use current directory
-	targetPath, err := os.Getwd()
+	// Use the -C directory or the current directory; no parent lookup, init creates .archie here
+	targetPath, err := startDir()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to get current directory: %v", err))
-		return fmt.Errorf("failed to get current directory: %w", err)
+		ui.ShowError(err.Error())
+		return err
+	}
+
+	// Check the command names before touching the project
+	if _, err := agent.ResolveCommandNames(initEnabledCommandsFlag); err != nil {
+		ui.ShowError(err.Error())
+		return err
 	}
 
 	// Initialize project structure
@@ -52,78 +95,229 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("project initialization failed: %w", err)
 	}
 
-	// Agent selection and setup via TUI
+	cfg, err := config.Load(targetPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		return err
+	}
+
+	// Load custom agents (non-fatal if fails)
+	if err := agent.LoadAndRegister(targetPath); err != nil {
+		ui.ShowInfo("⚠️  Could not load custom agents: " + err.Error())
+		fmt.Println()
+	}
+
+	agentNames := initAgentFlag
+	if initCustomAgentFileFlag != "" {
+		name, err := registerCustomAgentFile(initCustomAgentFileFlag)
+		if err != nil {
+			ui.ShowError(err.Error())
+			return err
+		}
+		if !containsString(agentNames, name) {
+			agentNames = append(agentNames, name)
+		}
+	}
+
 	selector := agent.NewTUISelector(targetPath)
+	selector.SetDefaultAgent(cfg.Agent.Default)
+
+	if len(agentNames) == 0 {
+		if initYesFlag {
+			if cfg.Agent.Default == "" {
+				err := fmt.Errorf("no agent given: pass --agent or set agent.default in the config")
+				ui.ShowError(err.Error())
+				return err
+			}
+			agentNames = []string{cfg.Agent.Default}
+		} else {
+			// Agent selection via TUI (multi-select)
+			agentNames, err = selector.SelectAgents()
+			if err != nil {
+				ui.ShowError(fmt.Sprintf("Agent selection failed: %v", err))
+				return fmt.Errorf("agent selection failed: %w", err)
+			}
+		}
+		fmt.Println()
+	}
+
+	// Decide which agents to set up, confirming re-configuration of initialized ones
+	stateManager := agent.NewStateManager(nil)
+	var planned []plannedAgent
+	for _, agentName := range agentNames {
+		if containsPlanned(planned, agentName) {
+			continue
+		}
+		plan, ok, err := planAgent(selector, stateManager, targetPath, agentName)
+		if err != nil {
+			return err
+		}
+		if ok {
+			planned = append(planned, plan)
+		}
+	}
+
+	if len(planned) == 0 {
+		return nil
+	}
 
-	// Select agent
-	agentName, isCustom, err := selector.SelectAgent()
+	// Hashes recorded at install time reveal the files the user has edited
+	projectState, err := stateManager.Load(targetPath)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Agent selection failed: %v", err))
-		return fmt.Errorf("agent selection failed: %w", err)
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	var onModified agent.ModifiedHandler
+	if !initYesFlag && isInteractive() {
+		onModified = promptModifiedFile
+	}
+
+	// Setup every agent; a background doc shared by several agents is written once
+	setupper := agent.NewSetupper(nil)
+	writtenDocs := make(map[string]bool)
+	installed := projectState.InstalledHashes()
+	var editedFiles []agent.FileResult
+	states := make([]agent.AgentState, 0, len(planned))
+	for _, plan := range planned {
+		doc := filepath.Clean(agent.AgentDoc(plan.agent))
+		setupConfig := agent.SetupConfig{
+			ProjectPath: targetPath,
+			AgentType:   plan.name,
+			Options: agent.SetupOptions{
+				IncludeExamples: true,
+				EnabledCommands: initEnabledCommandsFlag,
+				SkipAgentDoc:    writtenDocs[doc],
+				Installed:       installed,
+				Force:           initForceFlag,
+				OnModified:      onModified,
+			},
+		}
+
+		report, err := setupper.SetupWithReport(ctx, setupConfig)
+		if err != nil {
+			ui.ShowError(fmt.Sprintf("Agent setup failed for %s: %v", plan.name, err))
+			return fmt.Errorf("agent setup failed: %w", err)
+		}
+		writtenDocs[doc] = true
+		for _, file := range report.Files {
+			switch file.Change {
+			case agent.FileKept, agent.FileNewCopy, agent.FileMerged, agent.FileConflict:
+				editedFiles = append(editedFiles, file)
+			}
+		}
+		states = append(states, agent.AgentState{
+			AgentName:       plan.name,
+			IsCustom:        plan.isCustom,
+			ArchieVersion:   version.Get(),
+			TemplateHash:    report.TemplateHash,
+			EnabledCommands: initEnabledCommandsFlag,
+			Files:           report.Manifest,
+		})
+	}
+
+	// Mark all agents as initialized in one state update
+	if err := stateManager.MarkInitialized(targetPath, states...); err != nil {
+		return fmt.Errorf("failed to update state: %w", err)
 	}
 
 	fmt.Println()
 
+	if len(editedFiles) > 0 {
+		ui.ShowInfo("Files you edited since they were installed:")
+		showFileResults(editedFiles)
+		fmt.Println()
+	}
+
+	// Show one success message for all agents set up in this run
+	showSuccessBox(targetPath, planned)
+
+	return nil
+}
+
+// plannedAgent is an agent selected for setup in this init run
+type plannedAgent struct {
+	name     string
+	agent    agent.Agent
+	isCustom bool
+}
+
+// planAgent resolves an agent name and, when it is already initialized, asks
+// whether to re-configure it. Returns false when the agent should be left as it is.
+func planAgent(selector *agent.TUISelector, stateManager *agent.StateManager, targetPath, agentName string) (plannedAgent, bool, error) {
+	selectedAgent, err := agent.Get(agentName)
+	if err != nil {
+		names := agent.Names()
+		sort.Strings(names)
+		ui.ShowError(fmt.Sprintf("Unknown agent '%s' (available: %s)", agentName, strings.Join(names, ", ")))
+		return plannedAgent{}, false, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	isCustom := false
+	if customAgent, ok := selectedAgent.(*agent.CustomAgent); ok {
+		isCustom = customAgent.IsCustom()
+	}
+
 	// Check if already initialized
-	stateManager := agent.NewStateManager(nil)
 	isInitialized, err := stateManager.IsInitialized(targetPath, agentName)
 	if err != nil {
-		return fmt.Errorf("failed to check agent state: %w", err)
+		return plannedAgent{}, false, fmt.Errorf("failed to check agent state: %w", err)
 	}
 
 	// Confirm reconfigure if needed
-	if isInitialized {
+	if isInitialized && !initYesFlag {
 		shouldReconfigure, err := selector.ConfirmReconfigure(agentName)
 		if err != nil {
-			return err
+			return plannedAgent{}, false, err
 		}
+		fmt.Println()
 		if !shouldReconfigure {
-			return nil
+			return plannedAgent{}, false, nil
 		}
-		fmt.Println()
 	}
 
-	// Setup agent
-	selectedAgent, err := agent.Get(agentName)
-	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to get agent: %v", err))
-		return fmt.Errorf("failed to get agent: %w", err)
-	}
+	return plannedAgent{name: agentName, agent: selectedAgent, isCustom: isCustom}, true, nil
+}
 
-	setupper := agent.NewSetupper(nil)
-	setupConfig := agent.SetupConfig{
-		ProjectPath: targetPath,
-		AgentType:   agentName,
-		Options: agent.SetupOptions{
-			IncludeExamples: true,
-		},
+// containsPlanned reports whether an agent is already planned
+func containsPlanned(planned []plannedAgent, name string) bool {
+	for _, plan := range planned {
+		if plan.name == name {
+			return true
+		}
 	}
+	return false
+}
 
-	if err := setupper.Setup(ctx, setupConfig); err != nil {
-		ui.ShowError(fmt.Sprintf("Agent setup failed: %v", err))
-		return fmt.Errorf("agent setup failed: %w", err)
+// registerCustomAgentFile saves the custom agent from a JSON file to the user
+// store and registers it, returning its name
+func registerCustomAgentFile(path string) (string, error) {
+	customConfig, err := agent.LoadCustomAgentFile(nil, path)
+	if err != nil {
+		return "", err
 	}
 
-	// Mark as initialized
-	if err := stateManager.MarkInitialized(targetPath, agentName, isCustom); err != nil {
-		return fmt.Errorf("failed to update state: %w", err)
+	if err := saveCustomAgent(agent.NewCustomAgentStore(nil), customConfig); err != nil {
+		return "", err
 	}
+	return customConfig.Name, nil
+}
 
-	fmt.Println()
-
-	// Show success message with all initialized agents
-	showSuccessBox(targetPath, agentName, selectedAgent.PathConfig())
-
-	return nil
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
 
-// showSuccessBox displays a nicely formatted success message
-func showSuccessBox(projectPath string, currentAgentName string, currentPathConfig agent.PathConfig) {
+// showSuccessBox displays a nicely formatted success message for the agents set up in this run
+func showSuccessBox(projectPath string, current []plannedAgent) {
 	// Get all initialized agents
 	stateManager := agent.NewStateManager(nil)
 	initializedAgents, err := stateManager.GetInitializedAgents(projectPath)
 	if err != nil {
-		// Fallback to showing only current agent if error
+		// Fallback to showing only current agents if error
 		initializedAgents = []agent.AgentState{}
 	}
 
@@ -143,8 +337,8 @@ func showSuccessBox(projectPath string, currentAgentName string, currentPathConf
 				agentType = "Custom"
 			}
 
-			// Highlight current agent
-			if ag.AgentName == currentAgentName {
+			// Highlight agents set up in this run
+			if containsPlanned(current, ag.AgentName) {
 				content = append(content, fmt.Sprintf("  %s%s%s (%s) %s← current%s",
 					ui.ColorBold, ag.AgentName, ui.ColorReset, agentType,
 					ui.ColorBrightYellow, ui.ColorReset))
@@ -156,10 +350,21 @@ func showSuccessBox(projectPath string, currentAgentName string, currentPathConf
 	}
 
 	content = append(content, ui.ColorGreen+"Generated:"+ui.ColorReset)
-	content = append(content, fmt.Sprintf("  Commands: %s/", currentPathConfig.CommandsDir))
+	docs := []string{}
+	for _, plan := range current {
+		pathConfig := plan.agent.PathConfig()
+		line := fmt.Sprintf("  %s: %s/", plan.name, pathConfig.CommandsDir)
+		if pathConfig.SubAgentsDir != "" {
+			line += fmt.Sprintf(", %s/", pathConfig.SubAgentsDir)
+		}
+		content = append(content, line)
 
-	if currentPathConfig.SubAgentsDir != "" {
-		content = append(content, fmt.Sprintf("  Sub-Agents: %s/", currentPathConfig.SubAgentsDir))
+		if doc := agent.AgentDoc(plan.agent); doc != "" && !containsString(docs, doc) {
+			docs = append(docs, doc)
+		}
+	}
+	if len(docs) > 0 {
+		content = append(content, fmt.Sprintf("  Background docs: %s", strings.Join(docs, ", ")))
 	}
 
 	content = append(content, "")
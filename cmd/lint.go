@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GarrickZ2/archie/internal/lint"
+	"github.com/GarrickZ2/archie/internal/ui"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [files...]",
+	Short: "Validate workspace documents against the schema templates",
+	Long: `Validate workspace documents against the schema templates shipped with Archie.
+
+Each workspace file is mapped to its schema the same way .archie/docs/schema.md
+describes (e.g. features/<feature-key>.md → feature.md). Headings and required
+fields are compared with the schema, and problems are reported as file:line.
+
+Reported problems:
+  - Missing sections and titles
+  - Sections out of order or at the wrong heading level
+  - Missing required fields (e.g. "- Owner:" in ## Status)
+
+Empty files and files without a schema are skipped.
+The command exits with a non-zero code when issues are found,
+so it can run in CI and pre-commit hooks.
+
+Examples:
+  # Lint the whole workspace
+  archie lint
+
+  # Lint specific files (e.g. from a pre-commit hook)
+  archie lint features/checkout-discount.md tasks.md`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runLint,
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	linter, err := lint.NewLinter(nil)
+	if err != nil {
+		return fmt.Errorf("failed to load schemas: %w", err)
+	}
+
+	var result *lint.Result
+	if len(args) > 0 {
+		// File arguments are relative to the working directory, which may be below the project root
+		files := make([]string, len(args))
+		for i, file := range args {
+			if files[i], err = filepath.Abs(file); err != nil {
+				return fmt.Errorf("invalid path %s: %w", file, err)
+			}
+		}
+		result, err = linter.LintFiles(projectPath, files)
+	} else {
+		result, err = linter.Lint(projectPath)
+	}
+	if err != nil {
+		return fmt.Errorf("lint failed: %w", err)
+	}
+
+	for _, issue := range result.Issues {
+		fmt.Println(issue.String())
+	}
+
+	if len(result.Issues) > 0 {
+		fmt.Println()
+		return fmt.Errorf("found %d issue(s) in %d checked file(s)", len(result.Issues), len(result.CheckedFiles))
+	}
+
+	ui.ShowSuccess(fmt.Sprintf("%d file(s) checked, no issues found", len(result.CheckedFiles)))
+	return nil
+}
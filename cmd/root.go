@@ -1,17 +1,38 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/GarrickZ2/archie/internal/project"
+	"github.com/GarrickZ2/archie/internal/version"
 )
 
+// projectDirFlag is the directory given with -C/--project
+var projectDirFlag string
+
 var rootCmd = &cobra.Command{
 	Use:   "archie",
 	Short: "Technical design documentation project initialization tool",
 	Long: `Archie is a CLI tool for initializing technical design documentation projects.
-It creates a standardized file structure to help teams quickly start writing technical design documents.`,
+It creates a standardized file structure to help teams quickly start writing technical design documents.
+
+Commands run from any subdirectory of a project: Archie walks up from the
+working directory (or the -C directory) to the nearest folder containing .archie.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if projectDirFlag == "" {
+			return nil
+		}
+		// Like git -C: relative paths in arguments and flags resolve against this directory
+		if err := os.Chdir(projectDirFlag); err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("cannot change to %s: %w", projectDirFlag, err)
+		}
+		return nil
+	},
 }
 
 // Execute runs the root command
@@ -23,6 +44,30 @@ func Execute() {
 }
 
 func init() {
-	// Future: add global flags here
-	// rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Verbose output")
+	rootCmd.Version = version.Get()
+	rootCmd.PersistentFlags().StringVarP(&projectDirFlag, "project", "C", "", "Run as if archie was started in this directory")
+}
+
+// startDir returns the working directory, which is the -C directory when it is given
+func startDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return dir, nil
+}
+
+// projectRoot returns the nearest directory containing .archie, starting from
+// startDir. Outside any project it returns startDir itself.
+func projectRoot() (string, error) {
+	dir, err := startDir()
+	if err != nil {
+		return "", err
+	}
+
+	root, err := project.NewRootFinder(nil).Find(dir)
+	if errors.Is(err, project.ErrProjectNotFound) {
+		return dir, nil
+	}
+	return root, err
 }
@@ -2,10 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/GarrickZ2/archie/internal/config"
 	"github.com/GarrickZ2/archie/internal/setup"
 	"github.com/GarrickZ2/archie/internal/ui"
 )
@@ -30,14 +30,21 @@ func init() {
 
 func runSetup(cmd *cobra.Command, args []string) error {
 	// Use current directory as project path
-	projectPath, err := os.Getwd()
+	projectPath, err := projectRoot()
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to get current directory: %v", err))
-		return fmt.Errorf("failed to get current directory: %w", err)
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		return err
 	}
 
 	// Create setup manager
 	manager := setup.NewManager(projectPath)
+	manager.SetEditor(cfg.Editor)
 
 	// Show main UI
 	return manager.ShowMainUI()
@@ -2,13 +2,14 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 
+	"github.com/GarrickZ2/archie/internal/config"
 	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/tasks"
 	"github.com/GarrickZ2/archie/internal/ui"
 )
 
@@ -16,6 +17,8 @@ var (
 	compactFlag  bool
 	overviewFlag bool
 	featureFlag  string
+	formatFlag   string
+	checkFlag    bool
 )
 
 var statusCmd = &cobra.Command{
@@ -32,7 +35,8 @@ Overall report:
 - Extracts status information from each feature
 - Shows overall progress, status distribution, and key insights
 - Highlights blocked features that need attention
-- Identifies stale features that haven't been updated recently
+- Identifies stale features that stayed in one status past its threshold
+- Flags statuses and owners over their WIP limits
 
 Detailed feature view:
 - Shows complete feature information in a structured TUI format
@@ -44,7 +48,16 @@ Statuses tracked:
   DESIGNED → SPEC_READY → IMPLEMENTING → FINISHED
 
 Special status:
-  BLOCKED - Features that are blocked and need attention`,
+  BLOCKED - Features that are blocked and need attention
+
+Stale thresholds and WIP limits are read from the status section of
+.archie/config.yaml (layered over ~/.archie/config.yaml). --check prints
+only the violations and exits non-zero when there are any, for standups
+and CI.
+
+Machine-readable output:
+  --format json|yaml prints the overview (default), the feature list (-f "")
+  or a single feature (-f <feature>) for scripts and dashboards.`,
 	Args: cobra.NoArgs,
 	RunE: runStatus,
 }
@@ -54,16 +67,34 @@ func init() {
 	statusCmd.Flags().BoolVarP(&compactFlag, "compact", "c", false, "Show compact status report")
 	statusCmd.Flags().BoolVarP(&overviewFlag, "overview", "o", false, "Show overview directly")
 	statusCmd.Flags().StringVarP(&featureFlag, "feature", "f", "", "Show feature list or specific feature detail (feature-key or file path)")
+	statusCmd.Flags().StringVar(&formatFlag, "format", "text", "Output format: text, json or yaml")
+	statusCmd.Flags().BoolVar(&checkFlag, "check", false, "Report stale features and WIP limit violations; exit non-zero if any")
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
-	// Get current directory as project path
-	projectPath, err := os.Getwd()
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	format, err := status.ParseOutputFormat(formatFlag)
 	if err != nil {
-		ui.ShowError(fmt.Sprintf("Failed to get current directory: %v", err))
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
+	}
+
+	if checkFlag {
+		return runStatusCheck(cmd, projectPath, format)
+	}
+
+	// Machine-readable output never prompts
+	if format != status.FormatText {
+		return writeStatusReport(cmd, projectPath, format)
 	}
 
+	// Installed agent prompts older than this Archie's templates
+	warnOutdatedAgents(projectPath)
+
 	// Handle direct mode flags
 	if overviewFlag {
 		return showOverallStatus(projectPath)
@@ -215,6 +246,17 @@ func showFeatureDetail(projectPath, featureKey string) error {
 
 	// Display detailed feature information
 	display := status.NewDetailDisplay(detail)
+	if taskList, err := tasks.NewParser(nil).ParseProject(projectPath); err == nil {
+		display.SetTasks(taskList.Feature(featureKey))
+	}
+	if blockers, err := status.NewBlockerParser(nil).ParseProject(projectPath); err == nil {
+		display.SetBlockers(blockers)
+	}
+	display.SetHistory(status.NewHistoryBuilder(nil).Build(projectPath, status.Feature{
+		Name:        detail.Key,
+		Status:      detail.Status,
+		LastUpdated: detail.LastUpdated,
+	}))
 	display.Show()
 
 	return nil
@@ -311,12 +353,42 @@ func showOverallStatus(projectPath string) error {
 		return nil
 	}
 
+	// Status timelines from the Changelogs (git history as a fallback)
+	histories := status.NewHistoryBuilder(nil).BuildAll(projectPath, features)
+
+	// Stale thresholds and WIP limits from the status section of the config
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		return err
+	}
+	policy := &cfg.Status
+
 	// Aggregate status information
 	aggregator := status.NewAggregator(features)
+	aggregator.SetHistories(histories)
+	aggregator.SetPolicy(policy)
 	summary := aggregator.Aggregate()
 
+	// Per-feature task completion from tasks.md
+	taskList, err := tasks.NewParser(nil).ParseProject(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to parse tasks: %v", err))
+		return fmt.Errorf("failed to parse tasks: %w", err)
+	}
+
 	// Display status report with dependency graph
 	display := status.NewDisplayWithFeatures(summary, features)
+	display.SetTasks(taskList)
+
+	// Blocker aging and consistency with BLOCKED features
+	blockers, err := status.NewBlockerParser(nil).ParseProject(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to parse blockers: %v", err))
+		return fmt.Errorf("failed to parse blockers: %w", err)
+	}
+	display.SetBlockers(blockers)
+	display.SetFlow(status.AnalyzeFlow(histories))
 
 	if compactFlag {
 		display.ShowCompact()
@@ -326,3 +398,85 @@ func showOverallStatus(projectPath string) error {
 
 	return nil
 }
+
+// writeStatusReport prints the overview, feature list or feature detail as JSON/YAML
+func writeStatusReport(cmd *cobra.Command, projectPath string, format status.OutputFormat) error {
+	out := cmd.OutOrStdout()
+
+	if featureFlag != "" {
+		featureKey := extractFeatureKey(featureFlag)
+		detail, err := status.NewDetailParser(nil).ParseFeatureDetail(projectPath, featureKey)
+		if err != nil {
+			return fmt.Errorf("feature '%s' not found", featureKey)
+		}
+		return status.WriteFormatted(out, format, detail)
+	}
+
+	features, err := status.NewParser(nil).ParseFeaturesDir(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse features: %w", err)
+	}
+
+	if cmd.Flags().Changed("feature") {
+		return status.WriteFormatted(out, format, status.SortFeaturesByStatus(features))
+	}
+
+	taskList, err := tasks.NewParser(nil).ParseProject(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse tasks: %w", err)
+	}
+
+	blockers, err := status.NewBlockerParser(nil).ParseProject(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse blockers: %w", err)
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return err
+	}
+	policy := &cfg.Status
+
+	histories := status.NewHistoryBuilder(nil).BuildAll(projectPath, features)
+	return status.WriteFormatted(out, format, status.NewOverviewReport(features, taskList, blockers, histories, policy))
+}
+
+// runStatusCheck reports stale features and WIP limit violations, failing when there are any
+func runStatusCheck(cmd *cobra.Command, projectPath string, format status.OutputFormat) error {
+	features, err := status.NewParser(nil).ParseFeaturesDir(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse features: %w", err)
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load config: %v", err))
+		return err
+	}
+	policy := &cfg.Status
+
+	aggregator := status.NewAggregator(features)
+	aggregator.SetHistories(status.NewHistoryBuilder(nil).BuildAll(projectPath, features))
+	aggregator.SetPolicy(policy)
+	violations := aggregator.Aggregate().Violations
+
+	if format != status.FormatText {
+		if err := status.WriteFormatted(cmd.OutOrStdout(), format, violations); err != nil {
+			return err
+		}
+	} else if len(violations) == 0 {
+		ui.ShowSuccess("No stale features or WIP limit violations")
+	} else {
+		fmt.Println()
+		for _, violation := range violations {
+			fmt.Printf("  • %s\n", violation.String())
+		}
+		fmt.Println()
+	}
+
+	if len(violations) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("found %d status policy violation(s)", len(violations))
+	}
+	return nil
+}
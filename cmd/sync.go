@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/GarrickZ2/archie/internal/agent"
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/ui"
+	"github.com/GarrickZ2/archie/internal/version"
+)
+
+var (
+	syncFormatFlag string
+	syncForceFlag  bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Refresh installed agent files after upgrading Archie",
+	Long: `Re-install the commands, sub-agents and background docs of every agent
+recorded in .archie/state.json, and refresh the schema docs in .archie/docs.
+
+Each agent is installed with the commands that were enabled when it was
+initialized. Every file is reported as added, updated, unchanged or removed.
+The Archie version and a hash of the installed templates are recorded, so
+'archie status' can warn when the prompts are out of date.
+
+A file whose content no longer matches the hash recorded at install time was
+edited by you. For each such file you choose to keep it, overwrite it, write
+the new template next to it as <file>.new, or merge your edits with the new
+template (conflicts are marked like git). Without a terminal, or with
+--format json/yaml, a .new copy is written. --force overwrites edited files.
+
+Examples:
+  # After go install of a newer Archie
+  archie sync
+
+  # Discard local edits to the installed prompts
+  archie sync --force
+
+  # Machine-readable summary
+  archie sync --format json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runSync,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().StringVar(&syncFormatFlag, "format", "text", "Output format: text, json or yaml")
+	syncCmd.Flags().BoolVar(&syncForceFlag, "force", false, "Overwrite installed files you have edited")
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	format, err := status.ParseOutputFormat(syncFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	// Load custom agents (non-fatal if fails)
+	if err := agent.LoadAndRegister(projectPath); err != nil {
+		ui.ShowInfo("⚠️  Could not load custom agents: " + err.Error())
+		fmt.Println()
+	}
+
+	syncer := agent.NewSyncer(nil)
+	syncer.SetForce(syncForceFlag)
+	if format == status.FormatText && isInteractive() {
+		syncer.SetModifiedHandler(promptModifiedFile)
+	}
+
+	result, err := syncer.Sync(context.Background(), projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Sync failed: %v", err))
+		return err
+	}
+
+	if format != status.FormatText {
+		return status.WriteFormatted(cmd.OutOrStdout(), format, result)
+	}
+
+	fmt.Println()
+	for _, agentResult := range result.Agents {
+		fmt.Printf("  %s%s%s\n", ui.ColorBold, agentResult.Agent, ui.ColorReset)
+		if agentResult.Skipped != "" {
+			fmt.Printf("    %sskipped: %s%s\n", ui.ColorDim, agentResult.Skipped, ui.ColorReset)
+		}
+		showFileResults(agentResult.Files)
+		fmt.Println()
+	}
+
+	fmt.Printf("  %s.archie/docs%s\n", ui.ColorBold, ui.ColorReset)
+	showFileResults(result.Docs)
+	fmt.Println()
+
+	counts := result.Counts()
+	summary := fmt.Sprintf("Synced %d agent(s) with Archie %s: %d added, %d updated, %d unchanged, %d removed",
+		len(result.Agents), version.Get(),
+		counts[agent.FileAdded], counts[agent.FileUpdated], counts[agent.FileUnchanged], counts[agent.FileRemoved])
+	ui.ShowSuccess(summary)
+	fmt.Println()
+	if counts[agent.FileConflict] > 0 {
+		ui.ShowInfo(fmt.Sprintf("⚠️  %d merged file(s) have conflict markers to resolve", counts[agent.FileConflict]))
+		fmt.Println()
+	}
+	return nil
+}
+
+// showFileResults prints one line per file with the change made to it
+func showFileResults(files []agent.FileResult) {
+	for _, file := range files {
+		switch file.Change {
+		case agent.FileAdded:
+			fmt.Printf("    %s+ %-50s added%s\n", ui.ColorGreen, file.Path, ui.ColorReset)
+		case agent.FileUpdated:
+			fmt.Printf("    %s~ %-50s updated%s\n", ui.ColorYellow, file.Path, ui.ColorReset)
+		case agent.FileRemoved:
+			fmt.Printf("    %s- %-50s removed%s\n", ui.ColorRed, file.Path, ui.ColorReset)
+		case agent.FileNewCopy:
+			fmt.Printf("    %s! %-50s edited, new template in %s.new%s\n", ui.ColorYellow, file.Path, file.Path, ui.ColorReset)
+		case agent.FileConflict:
+			fmt.Printf("    %s! %-50s merged with conflicts%s\n", ui.ColorRed, file.Path, ui.ColorReset)
+		case agent.FileMerged:
+			fmt.Printf("    %s~ %-50s merged%s\n", ui.ColorYellow, file.Path, ui.ColorReset)
+		default:
+			fmt.Printf("    %s  %-50s %s%s\n", ui.ColorDim, file.Path, file.Change, ui.ColorReset)
+		}
+	}
+}
+
+// warnOutdatedAgents prints a hint when installed agent prompts differ from this Archie's templates
+func warnOutdatedAgents(projectPath string) {
+	// Custom agents must be registered to compute their templates (non-fatal if it fails)
+	_ = agent.LoadAndRegister(projectPath)
+
+	outdated, err := agent.NewSyncer(nil).Outdated(projectPath)
+	if err != nil || len(outdated) == 0 {
+		return
+	}
+
+	names := make([]string, len(outdated))
+	for i, a := range outdated {
+		names[i] = a.Name
+		if a.InstalledVersion != "" {
+			names[i] += " (" + a.InstalledVersion + ")"
+		}
+	}
+	ui.ShowInfo(fmt.Sprintf("⚠️  Agent prompts are out of date for %s; run 'archie sync' to refresh them", strings.Join(names, ", ")))
+	fmt.Println()
+}
+
+// promptModifiedFile asks what to do with an installed file the user has edited
+func promptModifiedFile(file agent.ModifiedFile) (agent.Resolution, error) {
+	options := []string{
+		"Keep my version",
+		"Overwrite with the new template",
+		"Write the new template to " + file.Path + ".new",
+	}
+	resolutions := []agent.Resolution{agent.ResolveKeep, agent.ResolveOverwrite, agent.ResolveNewCopy}
+	if file.CanMerge {
+		options = append(options, "Merge my edits with the new template")
+		resolutions = append(resolutions, agent.ResolveMerge)
+	}
+
+	var choice int
+	prompt := &survey.Select{
+		Message: fmt.Sprintf("%s was edited since it was installed:", file.Path),
+		Options: options,
+		Default: options[len(options)-1],
+	}
+	if err := survey.AskOne(prompt, &choice); err != nil {
+		return "", err
+	}
+	return resolutions[choice], nil
+}
+
+// isInteractive reports whether stdin is a terminal, so prompts can be shown
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
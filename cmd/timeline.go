@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/tasks"
+	"github.com/GarrickZ2/archie/internal/timeline"
+	"github.com/GarrickZ2/archie/internal/ui"
+)
+
+var (
+	timelineFormatFlag  string
+	timelineMermaidFlag bool
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Show the task schedule, critical path and ETA conflicts",
+	Long: `Build a timeline from the tasks in tasks.md and the feature dependencies.
+
+Each task ends at its ETA (YYYY-MM-DD) and starts when its prerequisites end.
+Prerequisites are the tasks listed in "Depends on"; tasks without explicit
+dependencies also wait for the features their feature depends on.
+
+The command reports:
+  - The critical path: the chain of prerequisites behind the latest ETA
+  - Tasks whose ETA falls before the ETA of one of their prerequisites
+  - Tasks without an ETA
+
+Examples:
+  archie timeline
+  archie timeline --mermaid > timeline.md
+  archie timeline --format json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runTimeline,
+}
+
+func init() {
+	rootCmd.AddCommand(timelineCmd)
+	timelineCmd.Flags().StringVar(&timelineFormatFlag, "format", "text", "Output format: text, json or yaml")
+	timelineCmd.Flags().BoolVar(&timelineMermaidFlag, "mermaid", false, "Print only the Mermaid gantt block")
+}
+
+func runTimeline(cmd *cobra.Command, args []string) error {
+	projectPath, err := projectRoot()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to locate project: %v", err))
+		return err
+	}
+
+	format, err := status.ParseOutputFormat(timelineFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	taskList, err := tasks.NewParser(nil).ParseProject(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to parse tasks: %v", err))
+		return fmt.Errorf("failed to parse tasks: %w", err)
+	}
+
+	features, err := status.NewParser(nil).ParseFeaturesDir(projectPath)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to parse features: %v", err))
+		return fmt.Errorf("failed to parse features: %w", err)
+	}
+
+	result := timeline.Build(taskList, status.BuildDependencyGraph(features))
+
+	switch {
+	case timelineMermaidFlag:
+		fmt.Fprint(cmd.OutOrStdout(), result.Mermaid())
+	case format != status.FormatText:
+		return status.WriteFormatted(cmd.OutOrStdout(), format, result)
+	default:
+		timeline.NewDisplay(result).Show()
+	}
+
+	return nil
+}
@@ -47,11 +47,22 @@ type SetupConfig struct {
 type SetupOptions struct {
 	IncludeExamples bool                   // 是否包含示例
 	EnabledCommands []string               // 启用的命令（空则全部启用）
+	SkipAgentDoc    bool                   // 不写背景文档（同一次 init 中其他 agent 已写过同一个文件）
 	CustomConfig    map[string]interface{} // 自定义配置
+
+	// Installed 上次安装时记录的 path → hash（来自 state.json 的 manifest），
+	// 磁盘上的内容与记录不同说明用户修改过该文件。setup 会写回本次记录的 hash，
+	// 同一次运行中的下一个 agent 可以继续使用这个 map
+	Installed  map[string]string
+	Force      bool            // 直接覆盖用户修改过的文件
+	OnModified ModifiedHandler // 决定如何处理用户修改过的文件（nil 时写入 .new 副本）
 }
 
 // Setupper 定义 agent setup 的接口
 type Setupper interface {
 	// Setup 在项目中 setup agent 配置
 	Setup(ctx context.Context, config SetupConfig) error
+
+	// SetupWithReport 与 Setup 相同，并返回每个文件的变化和模板 hash
+	SetupWithReport(ctx context.Context, config SetupConfig) (*SetupReport, error)
 }
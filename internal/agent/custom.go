@@ -1,10 +1,14 @@
 package agent
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/afero"
 )
@@ -15,14 +19,25 @@ type CustomAgentConfig struct {
 	AgentDoc     string            `json:"background_doc"`
 	CommandsDir  string            `json:"commands_dir"`
 	SubAgentsDir string            `json:"sub_agents_dir"`
-	Official     bool              `json:"official"` // 是否为官方 agent
-	FileFormat   string            `json:"file_format"` // "md" 或 "toml"
-	Mapping      map[string]string `json:"mapping"`    // YAML 字段到目标格式的映射
+	Official     bool              `json:"official"`    // 是否为官方 agent
+	FileFormat   string            `json:"file_format"` // 注册的格式：md、mdc、toml、json、yaml
+	Mapping      map[string]string `json:"mapping"`     // YAML 字段到目标格式的映射
+
+	// FileExtension 命令文件的扩展名（不含点），为空时使用格式的默认扩展名
+	FileExtension string `json:"file_extension,omitempty"`
+
+	// FilenameTemplate 命令文件在 commands_dir 中的文件名，支持 {{project}}、{{command}}、{{ext}}，
+	// 可以包含子目录（如 archie/{{command}}）。为空时为 {{command}}.{{ext}}
+	FilenameTemplate string `json:"filename_template,omitempty"`
+
+	// SubAgentMapping sub-agent 模板字段（frontmatter 和正文）到目标格式的映射，为空时使用格式的默认 mapping
+	SubAgentMapping map[string]string `json:"sub_agent_mapping,omitempty"`
 }
 
 // CustomAgent 自定义 agent 实现
 type CustomAgent struct {
 	config CustomAgentConfig
+	scope  StoreScope // 来自哪个存储（内置 agent 为空）
 }
 
 // NewCustomAgent 创建自定义 agent
@@ -34,24 +49,42 @@ func NewCustomAgent(config CustomAgentConfig) *CustomAgent {
 	if config.Mapping == nil {
 		config.Mapping = getDefaultMapping(config.FileFormat)
 	}
+	if config.SubAgentMapping == nil {
+		config.SubAgentMapping = getDefaultMapping(config.FileFormat)
+	}
 	return &CustomAgent{config: config}
 }
 
-// getDefaultMapping 获取默认的 mapping 配置
+// getDefaultMapping 获取格式默认的 mapping 配置（未知格式使用 md 的）
 func getDefaultMapping(fileFormat string) map[string]string {
-	switch fileFormat {
-	case "toml":
-		return map[string]string{
-			"description": "description",
-			"content":     "prompt",
-		}
-	case "md":
-		fallthrough
-	default:
-		return map[string]string{
-			"content": "[CONTENT]",
-		}
+	spec, err := GetFormat(fileFormat)
+	if err != nil {
+		spec, _ = GetFormat("md")
 	}
+	mapping := make(map[string]string, len(spec.DefaultMapping))
+	for key, target := range spec.DefaultMapping {
+		mapping[key] = target
+	}
+	return mapping
+}
+
+// FileExtension 返回命令文件的扩展名（不含点）
+func (a *CustomAgent) FileExtension() string {
+	if a.config.FileExtension != "" {
+		return strings.TrimPrefix(a.config.FileExtension, ".")
+	}
+	if spec, err := GetFormat(a.config.FileFormat); err == nil {
+		return spec.Extension
+	}
+	return a.config.FileFormat
+}
+
+// FilenameTemplate 返回命令文件名模板
+func (a *CustomAgent) FilenameTemplate() string {
+	if a.config.FilenameTemplate != "" {
+		return a.config.FilenameTemplate
+	}
+	return defaultFilenameTemplate
 }
 
 // Name 返回 agent 名称
@@ -78,13 +111,18 @@ func (a *CustomAgent) Commands() map[string]string {
 	return formatted
 }
 
-// SubAgents 返回子 agent 提示
+// SubAgents 返回按 sub_agent_mapping 格式化后的子 agent 提示
 func (a *CustomAgent) SubAgents() map[string]string {
 	if a.config.SubAgentsDir == "" {
 		return nil
 	}
 
-	return GetSubAgents()
+	formatted, err := GetFormattedSubAgents(a.config.Name)
+	if err != nil {
+		// 如果格式化失败，返回空 map
+		return make(map[string]string)
+	}
+	return formatted
 }
 
 // SupportsSubAgents 返回是否支持子 agents
@@ -102,17 +140,60 @@ func (a *CustomAgent) IsOfficial() bool {
 	return a.config.Official
 }
 
+// Scope 返回定义该 agent 的存储（内置 agent 为空）
+func (a *CustomAgent) Scope() StoreScope {
+	return a.scope
+}
+
+// StoreScope 自定义 agent 的存储位置
+type StoreScope string
+
+const (
+	// ScopeUser 用户根目录下的 ~/.archie/custom_agents.json，只对自己可见
+	ScopeUser StoreScope = "user"
+	// ScopeProject 项目中的 .archie/agents.json，随仓库提交，团队共享
+	ScopeProject StoreScope = "project"
+)
+
 // CustomAgentStore 自定义 agent 存储
 type CustomAgentStore struct {
-	fs afero.Fs
+	fs    afero.Fs
+	scope StoreScope
+	path  string // 项目存储的文件路径；用户存储在使用时解析
 }
 
-// NewCustomAgentStore 创建存储实例
+// NewCustomAgentStore 创建用户存储实例（~/.archie/custom_agents.json）
 func NewCustomAgentStore(fs afero.Fs) *CustomAgentStore {
 	if fs == nil {
 		fs = afero.NewOsFs()
 	}
-	return &CustomAgentStore{fs: fs}
+	return &CustomAgentStore{fs: fs, scope: ScopeUser}
+}
+
+// NewProjectAgentStore 创建项目存储实例（<projectPath>/.archie/agents.json）
+func NewProjectAgentStore(fs afero.Fs, projectPath string) *CustomAgentStore {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &CustomAgentStore{fs: fs, scope: ScopeProject, path: ProjectAgentsPath(projectPath)}
+}
+
+// ProjectAgentsPath 返回项目自定义 agents 文件的路径
+func ProjectAgentsPath(projectPath string) string {
+	return filepath.Join(projectPath, ".archie", "agents.json")
+}
+
+// Scope 返回存储位置
+func (s *CustomAgentStore) Scope() StoreScope {
+	return s.scope
+}
+
+// configPath 返回存储文件的路径
+func (s *CustomAgentStore) configPath() (string, error) {
+	if s.path != "" {
+		return s.path, nil
+	}
+	return getGlobalConfigPath()
 }
 
 // getGlobalConfigPath 获取全局配置文件路径（用户根目录）
@@ -124,9 +205,9 @@ func getGlobalConfigPath() (string, error) {
 	return filepath.Join(homeDir, ".archie", "custom_agents.json"), nil
 }
 
-// Save 保存自定义 agent 配置到用户根目录
+// Save 保存自定义 agent 配置到存储文件
 func (s *CustomAgentStore) Save(configs []CustomAgentConfig) error {
-	configPath, err := getGlobalConfigPath()
+	configPath, err := s.configPath()
 	if err != nil {
 		return err
 	}
@@ -151,9 +232,9 @@ func (s *CustomAgentStore) Save(configs []CustomAgentConfig) error {
 	return nil
 }
 
-// Load 加载自定义 agent 配置从用户根目录
+// Load 从存储文件加载自定义 agent 配置
 func (s *CustomAgentStore) Load() ([]CustomAgentConfig, error) {
-	configPath, err := getGlobalConfigPath()
+	configPath, err := s.configPath()
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +257,7 @@ func (s *CustomAgentStore) Load() ([]CustomAgentConfig, error) {
 	// 反序列化
 	var configs []CustomAgentConfig
 	if err := json.Unmarshal(data, &configs); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", configPath, err)
 	}
 
 	return configs, nil
@@ -204,6 +285,24 @@ func (s *CustomAgentStore) Add(config CustomAgentConfig) error {
 	return s.Save(configs)
 }
 
+// Put 添加或替换同名的自定义 agent
+func (s *CustomAgentStore) Put(config CustomAgentConfig) error {
+	configs, err := s.Load()
+	if err != nil {
+		return err
+	}
+
+	for i, c := range configs {
+		if c.Name == config.Name {
+			configs[i] = config
+			return s.Save(configs)
+		}
+	}
+
+	configs = append(configs, config)
+	return s.Save(configs)
+}
+
 // Remove 删除自定义 agent
 func (s *CustomAgentStore) Remove(agentName string) error {
 	// 加载现有配置
@@ -231,20 +330,143 @@ func (s *CustomAgentStore) Remove(agentName string) error {
 	return s.Save(newConfigs)
 }
 
-// LoadAndRegister 加载并注册所有自定义 agents（从用户根目录）
-func LoadAndRegister() error {
-	store := NewCustomAgentStore(nil)
-	configs, err := store.Load()
+// LoadAndRegister 加载并注册所有自定义 agents。优先级从低到高：内置 agents.json、
+// 用户存储 ~/.archie/custom_agents.json、项目存储 .archie/agents.json，
+// 同名时后注册的覆盖先注册的。projectPath 为空时只加载用户存储
+func LoadAndRegister(projectPath string) error {
+	stores := []*CustomAgentStore{NewCustomAgentStore(nil)}
+	if projectPath != "" {
+		stores = append(stores, NewProjectAgentStore(nil, projectPath))
+	}
+
+	// 一个存储读取失败时仍然加载其他存储
+	var errs []error
+	for _, store := range stores {
+		if err := store.Register(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Register 注册存储中的所有 agents，覆盖已注册的同名自定义 agent
+// 与官方 agent 同名的配置不会注册，并作为错误返回
+func (s *CustomAgentStore) Register() error {
+	configs, err := s.Load()
 	if err != nil {
 		return err
 	}
-
+	var errs []error
 	for _, config := range configs {
-		agent := NewCustomAgent(config)
-		Register(agent)
+		if IsOfficialName(config.Name) {
+			errs = append(errs, fmt.Errorf("custom agent '%s' in the %s store is ignored: the name belongs to an official agent", config.Name, s.scope))
+			continue
+		}
+		s.RegisterAgent(config)
 	}
+	return errors.Join(errs...)
+}
 
-	return nil
+// RegisterAgent 注册一个来自该存储的 agent
+func (s *CustomAgentStore) RegisterAgent(config CustomAgentConfig) {
+	agent := NewCustomAgent(config)
+	agent.scope = s.scope
+	Register(agent)
+}
+
+// LoadCustomAgentFile 从 JSON 文件读取一个自定义 agent 配置（格式与 custom_agents.json 中的一项相同）
+func LoadCustomAgentFile(fs afero.Fs, path string) (CustomAgentConfig, error) {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	var config CustomAgentConfig
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return config, fmt.Errorf("failed to read custom agent file: %w", err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse custom agent file %s: %w", path, err)
+	}
+	return importedConfig(config, path)
+}
+
+// LoadCustomAgentsFile 从 JSON 文件读取自定义 agent 配置，文件可以是一个 agent
+// 或 agent 数组（archie custom-agent export 的输出）
+func LoadCustomAgentsFile(fs afero.Fs, path string) ([]CustomAgentConfig, error) {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom agent file: %w", err)
+	}
+
+	var configs []CustomAgentConfig
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(data, &configs); err != nil {
+			return nil, fmt.Errorf("failed to parse custom agent file %s: %w", path, err)
+		}
+	} else {
+		var config CustomAgentConfig
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse custom agent file %s: %w", path, err)
+		}
+		configs = []CustomAgentConfig{config}
+	}
+
+	for i := range configs {
+		if configs[i], err = importedConfig(configs[i], path); err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
+// importedConfig 补全从文件导入的配置并验证
+func importedConfig(config CustomAgentConfig, path string) (CustomAgentConfig, error) {
+	// 从文件导入的 agent 总是自定义的
+	config.Official = false
+	if config.AgentDoc == "" {
+		config.AgentDoc = "AGENTS.md"
+	}
+	if err := ValidateCustomAgentConfig(config); err != nil {
+		return config, fmt.Errorf("invalid custom agent '%s' in %s: %w", config.Name, path, err)
+	}
+	return config, nil
+}
+
+// LoadCustomAgents 返回用户存储和项目存储中的自定义 agents，同名时项目存储优先，按名称排序
+// projectPath 为空时只读取用户存储
+func LoadCustomAgents(projectPath string) ([]CustomAgentConfig, error) {
+	stores := []*CustomAgentStore{NewCustomAgentStore(nil)}
+	if projectPath != "" {
+		stores = append(stores, NewProjectAgentStore(nil, projectPath))
+	}
+
+	byName := make(map[string]CustomAgentConfig)
+	for _, store := range stores {
+		configs, err := store.Load()
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range configs {
+			if !config.Official {
+				byName[config.Name] = config
+			}
+		}
+	}
+
+	configs := make([]CustomAgentConfig, 0, len(byName))
+	for _, config := range byName {
+		configs = append(configs, config)
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Name < configs[j].Name
+	})
+	return configs, nil
 }
 
 // GetConfigFilePath 获取配置文件路径（用于检查）
@@ -252,34 +474,96 @@ func GetConfigFilePath() (string, error) {
 	return getGlobalConfigPath()
 }
 
+// FormatMapping 将 mapping 格式化为 "field=target, ..."（按字段排序）
+func FormatMapping(mapping map[string]string) string {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + mapping[key]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// ParseMapping 解析 FormatMapping 的格式，空字符串返回 nil（使用格式的默认 mapping）
+func ParseMapping(text string) (map[string]string, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(text, ",") {
+		field, target, ok := strings.Cut(pair, "=")
+		field, target = strings.TrimSpace(field), strings.TrimSpace(target)
+		if !ok || field == "" || target == "" {
+			return nil, fmt.Errorf("invalid mapping entry %q (expected field=target)", strings.TrimSpace(pair))
+		}
+		mapping[field] = target
+	}
+	return mapping, nil
+}
+
 // ValidateCustomAgentConfig 验证自定义 agent 配置
 func ValidateCustomAgentConfig(config CustomAgentConfig) error {
 	if config.Name == "" {
 		return fmt.Errorf("agent name cannot be empty")
 	}
+	// 官方 agent 的名称保留，自定义 agent 不能覆盖
+	if IsOfficialName(config.Name) {
+		return fmt.Errorf("'%s' is the name of an official agent; choose another name", config.Name)
+	}
 	if config.AgentDoc == "" {
 		config.AgentDoc = "AGENTS.md" // 设置默认值
 	}
 	if config.CommandsDir == "" {
 		return fmt.Errorf("commands directory cannot be empty")
 	}
-	// 验证 file_format
-	if config.FileFormat != "" && config.FileFormat != "md" && config.FileFormat != "toml" {
-		return fmt.Errorf("file_format must be 'md' or 'toml'")
-	}
-	// 验证 mapping 必须包含正文字段的映射
-	if config.Mapping != nil {
-		hasContent := false
-		for _, target := range config.Mapping {
-			if target == "[CONTENT]" || target == "prompt" {
-				hasContent = true
-				break
-			}
+	// 验证 file_format 是已注册的格式
+	fileFormat := config.FileFormat
+	if fileFormat == "" {
+		fileFormat = "md"
+	}
+	spec, err := GetFormat(fileFormat)
+	if err != nil {
+		return fmt.Errorf("file_format: %w", err)
+	}
+	// 验证 file_extension 只是扩展名，不含路径
+	if config.FileExtension != "" {
+		ext := strings.TrimPrefix(config.FileExtension, ".")
+		if ext == "" || strings.ContainsAny(ext, `/\.`) {
+			return fmt.Errorf("file_extension '%s' is not a valid extension", config.FileExtension)
 		}
-		if !hasContent {
-			return fmt.Errorf("mapping must include a content field mapping ([CONTENT] for md or 'prompt' for toml)")
+	}
+	// 验证 filename_template
+	if config.FilenameTemplate != "" {
+		if err := validateFilenameTemplate(config.FilenameTemplate); err != nil {
+			return err
 		}
 	}
+	// 验证 mapping 和 sub_agent_mapping 必须包含正文字段的映射
+	if err := validateMapping("mapping", config.Mapping, spec, fileFormat); err != nil {
+		return err
+	}
+	if err := validateMapping("sub_agent_mapping", config.SubAgentMapping, spec, fileFormat); err != nil {
+		return err
+	}
 	// SubAgentsDir 可选，所以不检查
 	return nil
 }
+
+// validateMapping 检查 mapping 包含正文字段的映射（nil 表示使用默认 mapping）
+func validateMapping(name string, mapping map[string]string, spec FormatSpec, fileFormat string) error {
+	if mapping == nil {
+		return nil
+	}
+	for field, target := range mapping {
+		if field == "content" || target == spec.ContentTarget || target == "[CONTENT]" || target == "prompt" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must include a content field mapping (content=%s for %s)", name, spec.ContentTarget, fileFormat)
+}
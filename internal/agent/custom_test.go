@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestLoadAndRegister_ProjectOverridesUser(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	projectPath := t.TempDir()
+
+	user := NewCustomAgentStore(nil)
+	if err := user.Save([]CustomAgentConfig{
+		{Name: "test-shared-agent", AgentDoc: "AGENTS.md", CommandsDir: ".user/commands"},
+		{Name: "test-user-agent", AgentDoc: "AGENTS.md", CommandsDir: ".mine/commands"},
+	}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	project := NewProjectAgentStore(nil, projectPath)
+	if err := project.Add(CustomAgentConfig{Name: "test-shared-agent", AgentDoc: "AGENTS.md", CommandsDir: ".team/commands"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := LoadAndRegister(projectPath); err != nil {
+		t.Fatalf("LoadAndRegister() error = %v", err)
+	}
+
+	shared, err := Get("test-shared-agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir := shared.PathConfig().CommandsDir; dir != ".team/commands" {
+		t.Errorf("CommandsDir = %q, want the project definition .team/commands", dir)
+	}
+	if scope := shared.(*CustomAgent).Scope(); scope != ScopeProject {
+		t.Errorf("Scope() = %q, want %q", scope, ScopeProject)
+	}
+
+	mine, err := Get("test-user-agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scope := mine.(*CustomAgent).Scope(); scope != ScopeUser {
+		t.Errorf("Scope() = %q, want %q", scope, ScopeUser)
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	mapping, err := ParseMapping(" description = description, content=[CONTENT] ")
+	if err != nil {
+		t.Fatalf("ParseMapping() error = %v", err)
+	}
+	if got := FormatMapping(mapping); got != "content=[CONTENT], description=description" {
+		t.Errorf("FormatMapping() = %q", got)
+	}
+
+	if mapping, err := ParseMapping(""); err != nil || mapping != nil {
+		t.Errorf("ParseMapping(\"\") = %v, %v, want nil (default mapping)", mapping, err)
+	}
+	if _, err := ParseMapping("content"); err == nil {
+		t.Error("ParseMapping() should reject an entry without a target")
+	}
+}
+
+func TestLoadCustomAgentsFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/one.json", []byte(`{"name": "one", "commands_dir": ".one", "official": true}`), 0644)
+	afero.WriteFile(fs, "/many.json", []byte(`[
+  {"name": "a", "commands_dir": ".a"},
+  {"name": "b", "commands_dir": ".b", "file_format": "toml"}
+]`), 0644)
+	afero.WriteFile(fs, "/invalid.json", []byte(`[{"name": "a", "commands_dir": ".a"}, {"name": "b"}]`), 0644)
+
+	configs, err := LoadCustomAgentsFile(fs, "/one.json")
+	if err != nil {
+		t.Fatalf("LoadCustomAgentsFile() error = %v", err)
+	}
+	if len(configs) != 1 || configs[0].Official || configs[0].AgentDoc != "AGENTS.md" {
+		t.Errorf("got %+v, want one custom agent with the default doc", configs)
+	}
+
+	if configs, err = LoadCustomAgentsFile(fs, "/many.json"); err != nil || len(configs) != 2 {
+		t.Errorf("LoadCustomAgentsFile() = %d agents, %v, want 2", len(configs), err)
+	}
+
+	if _, err := LoadCustomAgentsFile(fs, "/invalid.json"); err == nil {
+		t.Error("LoadCustomAgentsFile() should fail when any agent is invalid")
+	}
+}
+
+func TestOfficialNamesReserved(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := ValidateCustomAgentConfig(CustomAgentConfig{Name: "cursor", CommandsDir: ".mine"}); err == nil {
+		t.Error("ValidateCustomAgentConfig() should reject the name of an official agent")
+	}
+
+	// An entry edited into a store by hand is ignored instead of replacing the official agent
+	if err := NewCustomAgentStore(nil).Save([]CustomAgentConfig{
+		{Name: "cursor", AgentDoc: "AGENTS.md", CommandsDir: ".mine"},
+	}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := LoadAndRegister(""); err == nil {
+		t.Error("LoadAndRegister() should report the ignored entry")
+	}
+	cursor, err := Get("cursor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cursor.(*CustomAgent).IsOfficial() {
+		t.Error("the official cursor agent was replaced by a custom one")
+	}
+}
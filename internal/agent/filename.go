@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultFilenameTemplate 默认的命令文件名：模板名加格式扩展名（如 archie-design.md）
+const defaultFilenameTemplate = "{{command}}.{{ext}}"
+
+// filenamePlaceholder 匹配文件名模板中的占位符
+var filenamePlaceholder = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+
+// filenamePlaceholders 支持的占位符
+var filenamePlaceholders = map[string]bool{
+	"{{project}}": true, // 项目目录名加路径哈希，如 shop-1a2b3c4d
+	"{{command}}": true, // 命令模板名，如 archie-design
+	"{{ext}}":     true, // 文件扩展名，不含点
+}
+
+// validateFilenameTemplate 检查文件名模板：只能使用已知占位符，必须包含 {{command}}，
+// 并且解析后留在 commands_dir 内
+func validateFilenameTemplate(tmpl string) error {
+	for _, placeholder := range filenamePlaceholder.FindAllString(tmpl, -1) {
+		if !filenamePlaceholders[placeholder] {
+			return fmt.Errorf("filename_template: unknown placeholder %s (available: {{project}}, {{command}}, {{ext}})", placeholder)
+		}
+	}
+	if !strings.Contains(tmpl, "{{command}}") {
+		return fmt.Errorf("filename_template must contain {{command}}, otherwise every command is written to the same file")
+	}
+
+	slashed := filepath.ToSlash(tmpl)
+	if path.IsAbs(slashed) || strings.HasPrefix(slashed, "~") {
+		return fmt.Errorf("filename_template must be relative to commands_dir")
+	}
+	for _, part := range strings.Split(slashed, "/") {
+		if part == ".." {
+			return fmt.Errorf("filename_template must not leave commands_dir")
+		}
+	}
+	return nil
+}
+
+// filenameTemplate 返回 agent 的命令文件名模板
+func filenameTemplate(agent Agent) string {
+	if customAgent, ok := agent.(*CustomAgent); ok {
+		return customAgent.FilenameTemplate()
+	}
+	return defaultFilenameTemplate
+}
+
+// commandFilename 按模板解析命令文件在 commands_dir 中的路径。filename 是格式化后的
+// 文件名（如 archie-design.md）；模板没有 {{ext}} 时补上扩展名
+func commandFilename(tmpl, project, filename string) string {
+	ext := filepath.Ext(filename)
+	if ext != "" && !strings.Contains(tmpl, "{{ext}}") {
+		tmpl += ".{{ext}}"
+	}
+	replacer := strings.NewReplacer(
+		"{{project}}", project,
+		"{{command}}", strings.TrimSuffix(filename, ext),
+		"{{ext}}", strings.TrimPrefix(ext, "."),
+	)
+	return filepath.FromSlash(replacer.Replace(tmpl))
+}
+
+// projectName 返回 {{project}} 的值：项目目录名加绝对路径的短哈希。
+// 同名的两个检出目录共享全局 commands_dir 时不会写到同一个文件
+func projectName(projectPath string) string {
+	if projectPath == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	sum := sha256.Sum256([]byte(projectPath))
+	return filepath.Base(projectPath) + "-" + hex.EncodeToString(sum[:])[:8]
+}
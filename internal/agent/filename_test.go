@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommandFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     string
+		filename string
+		expected string
+	}{
+		{"default", defaultFilenameTemplate, "archie-design.md", "archie-design.md"},
+		{"project prefix", "{{project}}-{{command}}.{{ext}}", "archie-design.md", "shop-archie-design.md"},
+		{"subdirectory without ext", "archie/{{command}}", "archie-design.toml", filepath.Join("archie", "archie-design.toml")},
+		{"custom extension", "{{command}}.prompt.{{ext}}", "archie-spec.md", "archie-spec.prompt.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commandFilename(tt.tmpl, "shop", tt.filename); got != tt.expected {
+				t.Errorf("commandFilename(%q, %q) = %q, want %q", tt.tmpl, tt.filename, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProjectName(t *testing.T) {
+	first, second := projectName("/a/shop"), projectName("/b/shop")
+	if !strings.HasPrefix(first, "shop-") || !strings.HasPrefix(second, "shop-") {
+		t.Errorf("projectName() = %q, %q, want the directory name first", first, second)
+	}
+	if first == second {
+		t.Errorf("projectName() = %q for two different paths", first)
+	}
+	if again := projectName("/a/shop/"); again != first {
+		t.Errorf("projectName(/a/shop/) = %q, want %q", again, first)
+	}
+}
+
+func TestValidateFilenameTemplate(t *testing.T) {
+	tests := []struct {
+		tmpl    string
+		wantErr bool
+	}{
+		{"{{project}}-{{command}}.{{ext}}", false},
+		{"archie/{{command}}", false},
+		{"{{project}}.{{ext}}", true},
+		{"{{command}}-{{version}}", true},
+		{"../{{command}}", true},
+		{"/tmp/{{command}}", true},
+		{"~/{{command}}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			err := validateFilenameTemplate(tt.tmpl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateFilenameTemplate(%q) error = %v, wantErr %v", tt.tmpl, err, tt.wantErr)
+			}
+		})
+	}
+}
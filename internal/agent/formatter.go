@@ -1,8 +1,12 @@
 package agent
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/GarrickZ2/archie/resources"
 	"gopkg.in/yaml.v3"
@@ -17,106 +21,122 @@ type Formatter interface {
 type MDFormatter struct{}
 
 // Format 将 CommandTemplate 格式化为 Markdown（带 frontmatter）
+// frontmatter 按模板中的键顺序输出，结束的 --- 后直接接正文，
+// 所以默认映射下 Markdown 模板原样输出
 func (f *MDFormatter) Format(template *resources.CommandTemplate, mapping map[string]string) (string, error) {
-	// 构建 frontmatter
-	frontmatter := make(map[string]interface{})
+	// 处理 mapping：找到映射到正文（[CONTENT]）的字段
+	// 默认情况下，content 字段映射到正文，使用 template.Content
 	content := template.Content
-
-	// 处理 mapping：找到 content 字段的映射
-	// 默认情况下，content 字段映射到 [CONTENT]（正文）
-	contentMappedToContent := false
 	for yamlKey, targetKey := range mapping {
-		if targetKey == "[CONTENT]" {
-			if yamlKey == "content" {
-				// content 字段映射到正文，使用 template.Content
-				contentMappedToContent = true
-			} else {
-				// 其他字段映射到正文，从 metadata 中获取
-				if val, ok := template.Metadata[yamlKey]; ok {
-					if strVal, ok := val.(string); ok {
-						content = strVal
-					}
-				}
-			}
-			continue
-		}
-		// 其他字段映射到 frontmatter
-		if yamlKey == "content" {
-			// content 字段不映射到 [CONTENT]，跳过（不应该发生，但安全处理）
+		if targetKey != "[CONTENT]" || yamlKey == "content" {
 			continue
 		}
-		// 从 metadata 中获取值并映射到 frontmatter
+		// 其他字段映射到正文，从 metadata 中获取
 		if val, ok := template.Metadata[yamlKey]; ok {
-			frontmatter[targetKey] = val
+			if strVal, ok := val.(string); ok {
+				content = strVal
+			}
 		}
 	}
 
-	// 如果没有找到 content 映射，默认使用 template.Content
-	if !contentMappedToContent {
-		// 检查是否有其他字段映射到 [CONTENT]
-		hasContentMapping := false
-		for _, targetKey := range mapping {
-			if targetKey == "[CONTENT]" {
-				hasContentMapping = true
-				break
-			}
+	// 构建 frontmatter：映射过的字段使用目标键名，未映射的保留原名，映射到正文的跳过
+	frontmatter := &yaml.Node{Kind: yaml.MappingNode}
+	seen := make(map[string]bool)
+	for _, key := range metadataKeys(template) {
+		targetKey, mapped := mapping[key]
+		if !mapped {
+			targetKey = key
 		}
-		if !hasContentMapping {
-			// 默认：content 字段就是正文
-			content = template.Content
+		if targetKey == "[CONTENT]" || seen[targetKey] {
+			continue
 		}
-	}
+		seen[targetKey] = true
 
-	// 添加未映射的 metadata 字段到 frontmatter
-	for key, val := range template.Metadata {
-		// 检查这个字段是否已经在 frontmatter 中（通过 mapping）
-		mapped := false
-		for yamlKey, targetKey := range mapping {
-			if yamlKey == key {
-				if targetKey == "[CONTENT]" {
-					// 这个字段映射到正文，跳过
-					mapped = true
-				} else {
-					// 这个字段已经映射到 frontmatter，跳过
-					mapped = true
-				}
-				break
-			}
-		}
-		if !mapped {
-			frontmatter[key] = val
+		var value yaml.Node
+		if err := value.Encode(template.Metadata[key]); err != nil {
+			return "", fmt.Errorf("failed to marshal frontmatter field %s: %w", key, err)
 		}
+		frontmatter.Content = append(frontmatter.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: targetKey}, &value)
 	}
 
-	// 生成 frontmatter YAML
-	var frontmatterYAML string
-	if len(frontmatter) > 0 {
+	// 组合结果
+	var result strings.Builder
+	if len(frontmatter.Content) > 0 {
 		fmBytes, err := yaml.Marshal(frontmatter)
 		if err != nil {
 			return "", fmt.Errorf("failed to marshal frontmatter: %w", err)
 		}
-		frontmatterYAML = strings.TrimSpace(string(fmBytes))
-	}
-
-	// 组合结果
-	var result strings.Builder
-	if frontmatterYAML != "" {
 		result.WriteString("---\n")
-		result.WriteString(frontmatterYAML)
-		result.WriteString("\n---\n\n")
+		result.Write(fmBytes)
+		result.WriteString("---\n")
 	}
 	result.WriteString(content)
 
 	return result.String(), nil
 }
 
+// metadataKeys 返回模板 metadata 的键：先按模板文件中的顺序，
+// 再按字母序补上没有记录顺序的键
+func metadataKeys(template *resources.CommandTemplate) []string {
+	keys := make([]string, 0, len(template.Metadata))
+	listed := make(map[string]bool, len(template.Keys))
+	for _, key := range template.Keys {
+		if _, ok := template.Metadata[key]; ok && !listed[key] {
+			keys = append(keys, key)
+			listed[key] = true
+		}
+	}
+
+	var rest []string
+	for key := range template.Metadata {
+		if !listed[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
 // TOMLFormatter TOML 格式化器
 type TOMLFormatter struct{}
 
 // Format 将 CommandTemplate 格式化为 TOML
 func (f *TOMLFormatter) Format(template *resources.CommandTemplate, mapping map[string]string) (string, error) {
 	var result strings.Builder
+	tomlFields := structuredFields(template, mapping)
+
+	// 生成 TOML 内容（按键排序，保证同样的模板生成同样的文件）
+	// 注意：这里使用简单的格式，复杂的 TOML 可能需要专门的库
+	keys := make([]string, 0, len(tomlFields))
+	for key := range tomlFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		val := tomlFields[key]
+		switch v := val.(type) {
+		case string:
+			// 转义字符串中的特殊字符
+			escaped := escapeTOMLString(v)
+			result.WriteString(fmt.Sprintf("%s = %s\n", key, escaped))
+		case int, int64:
+			result.WriteString(fmt.Sprintf("%s = %d\n", key, v))
+		case float64:
+			result.WriteString(fmt.Sprintf("%s = %f\n", key, v))
+		case bool:
+			result.WriteString(fmt.Sprintf("%s = %v\n", key, v))
+		default:
+			// 对于复杂类型，转换为字符串
+			result.WriteString(fmt.Sprintf("%s = %q\n", key, fmt.Sprintf("%v", v)))
+		}
+	}
+
+	return result.String(), nil
+}
 
+// structuredFields 按 mapping 生成结构化格式（TOML、JSON、YAML）的字段：
+// 正文默认写入 prompt 字段，未映射的 metadata 原样保留
+func structuredFields(template *resources.CommandTemplate, mapping map[string]string) map[string]interface{} {
 	// 找到正文字段的映射（通常是 "prompt"）
 	contentKey := ""
 	for yamlKey, targetKey := range mapping {
@@ -130,8 +150,8 @@ func (f *TOMLFormatter) Format(template *resources.CommandTemplate, mapping map[
 		contentKey = "content"
 	}
 
-	// 构建 TOML 键值对
-	tomlFields := make(map[string]interface{})
+	// 构建键值对
+	fields := make(map[string]interface{})
 
 	// 首先处理 content 字段（正文字段）
 	if contentKey == "content" {
@@ -143,7 +163,7 @@ func (f *TOMLFormatter) Format(template *resources.CommandTemplate, mapping map[
 				break
 			}
 		}
-		tomlFields[targetContentKey] = template.Content
+		fields[targetContentKey] = template.Content
 	} else {
 		// contentKey 是 metadata 中的某个字段
 		if val, ok := template.Metadata[contentKey]; ok {
@@ -152,7 +172,7 @@ func (f *TOMLFormatter) Format(template *resources.CommandTemplate, mapping map[
 				if targetKey == "" {
 					targetKey = "prompt"
 				}
-				tomlFields[targetKey] = strVal
+				fields[targetKey] = strVal
 			}
 		}
 	}
@@ -166,7 +186,7 @@ func (f *TOMLFormatter) Format(template *resources.CommandTemplate, mapping map[
 			continue // content 已经处理
 		}
 		if val, ok := template.Metadata[yamlKey]; ok {
-			tomlFields[targetKey] = val
+			fields[targetKey] = val
 		}
 	}
 
@@ -184,31 +204,11 @@ func (f *TOMLFormatter) Format(template *resources.CommandTemplate, mapping map[
 			}
 		}
 		if !mapped {
-			tomlFields[key] = val
-		}
-	}
-
-	// 生成 TOML 内容
-	// 注意：这里使用简单的格式，复杂的 TOML 可能需要专门的库
-	for key, val := range tomlFields {
-		switch v := val.(type) {
-		case string:
-			// 转义字符串中的特殊字符
-			escaped := escapeTOMLString(v)
-			result.WriteString(fmt.Sprintf("%s = %s\n", key, escaped))
-		case int, int64:
-			result.WriteString(fmt.Sprintf("%s = %d\n", key, v))
-		case float64:
-			result.WriteString(fmt.Sprintf("%s = %f\n", key, v))
-		case bool:
-			result.WriteString(fmt.Sprintf("%s = %v\n", key, v))
-		default:
-			// 对于复杂类型，转换为字符串
-			result.WriteString(fmt.Sprintf("%s = %q\n", key, fmt.Sprintf("%v", v)))
+			fields[key] = val
 		}
 	}
 
-	return result.String(), nil
+	return fields
 }
 
 // escapeTOMLString 转义 TOML 字符串
@@ -224,14 +224,127 @@ func escapeTOMLString(s string) string {
 	return fmt.Sprintf(`"%s"`, escaped)
 }
 
+// JSONFormatter JSON 格式化器（字段与 TOML 相同）
+type JSONFormatter struct{}
+
+// Format 将 CommandTemplate 格式化为 JSON 对象
+func (f *JSONFormatter) Format(template *resources.CommandTemplate, mapping map[string]string) (string, error) {
+	data, err := json.MarshalIndent(structuredFields(template, mapping), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// YAMLFormatter YAML 格式化器（字段与 TOML 相同）
+type YAMLFormatter struct{}
+
+// Format 将 CommandTemplate 格式化为 YAML 文档
+func (f *YAMLFormatter) Format(template *resources.CommandTemplate, mapping map[string]string) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(structuredFields(template, mapping)); err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// FormatSpec 一种命令文件格式：格式化器、默认扩展名和默认 mapping
+type FormatSpec struct {
+	Formatter      Formatter
+	Extension      string            // 默认文件扩展名（不含点），agent 配置的 file_extension 可以覆盖
+	DefaultMapping map[string]string // agent 配置未指定 mapping 时使用
+	ContentTarget  string            // 正文的目标：[CONTENT]（正文）或字段名
+}
+
+// formatRegistry 格式注册表
+type formatRegistry struct {
+	mu    sync.RWMutex
+	specs map[string]FormatSpec
+}
+
+// formats 内置格式；以变量初始化，保证在 init() 加载内置 agents 之前可用
+var formats = &formatRegistry{
+	specs: map[string]FormatSpec{
+		"md": {
+			Formatter:      &MDFormatter{},
+			Extension:      "md",
+			DefaultMapping: map[string]string{"content": "[CONTENT]"},
+			ContentTarget:  "[CONTENT]",
+		},
+		// Cursor rules：带 description/globs/alwaysApply 等 frontmatter 的 Markdown
+		"mdc": {
+			Formatter:      &MDFormatter{},
+			Extension:      "mdc",
+			DefaultMapping: map[string]string{"description": "description", "content": "[CONTENT]"},
+			ContentTarget:  "[CONTENT]",
+		},
+		"toml": {
+			Formatter:      &TOMLFormatter{},
+			Extension:      "toml",
+			DefaultMapping: map[string]string{"description": "description", "content": "prompt"},
+			ContentTarget:  "prompt",
+		},
+		"json": {
+			Formatter:      &JSONFormatter{},
+			Extension:      "json",
+			DefaultMapping: map[string]string{"description": "description", "content": "prompt"},
+			ContentTarget:  "prompt",
+		},
+		"yaml": {
+			Formatter:      &YAMLFormatter{},
+			Extension:      "yaml",
+			DefaultMapping: map[string]string{"description": "description", "content": "prompt"},
+			ContentTarget:  "prompt",
+		},
+	},
+}
+
+// RegisterFormat 注册一种命令文件格式（同名覆盖）
+func RegisterFormat(name string, spec FormatSpec) {
+	formats.mu.Lock()
+	defer formats.mu.Unlock()
+	formats.specs[name] = spec
+}
+
+// GetFormat 获取文件格式的定义
+func GetFormat(fileFormat string) (FormatSpec, error) {
+	formats.mu.RLock()
+	defer formats.mu.RUnlock()
+
+	spec, ok := formats.specs[fileFormat]
+	if !ok {
+		return FormatSpec{}, fmt.Errorf("unsupported file format: %s (available: %s)", fileFormat, strings.Join(formatNames(), ", "))
+	}
+	return spec, nil
+}
+
+// FormatNames 返回所有已注册的文件格式，按名称排序
+func FormatNames() []string {
+	formats.mu.RLock()
+	defer formats.mu.RUnlock()
+	return formatNames()
+}
+
+// formatNames 调用方需持有锁
+func formatNames() []string {
+	names := make([]string, 0, len(formats.specs))
+	for name := range formats.specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // GetFormatter 根据文件格式获取格式化器
 func GetFormatter(fileFormat string) (Formatter, error) {
-	switch fileFormat {
-	case "md":
-		return &MDFormatter{}, nil
-	case "toml":
-		return &TOMLFormatter{}, nil
-	default:
-		return nil, fmt.Errorf("unsupported file format: %s", fileFormat)
+	spec, err := GetFormat(fileFormat)
+	if err != nil {
+		return nil, err
 	}
+	return spec.Formatter, nil
 }
@@ -0,0 +1,145 @@
+package agent
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GarrickZ2/archie/resources"
+)
+
+func TestFormatters_StructuredFormats(t *testing.T) {
+	template := &resources.CommandTemplate{
+		Metadata: map[string]interface{}{"description": "Review a design", "category": "review"},
+		Content:  "Read the design and list the risks.\n",
+	}
+	mapping := map[string]string{"description": "description", "content": "prompt"}
+
+	for _, name := range []string{"json", "yaml"} {
+		spec, err := GetFormat(name)
+		if err != nil {
+			t.Fatalf("GetFormat(%q) error = %v", name, err)
+		}
+		output, err := spec.Formatter.Format(template, mapping)
+		if err != nil {
+			t.Fatalf("%s Format() error = %v", name, err)
+		}
+
+		var fields map[string]interface{}
+		if name == "json" {
+			err = json.Unmarshal([]byte(output), &fields)
+		} else {
+			err = yaml.Unmarshal([]byte(output), &fields)
+		}
+		if err != nil {
+			t.Fatalf("%s output does not parse: %v\n%s", name, err, output)
+		}
+		if fields["prompt"] != template.Content {
+			t.Errorf("%s prompt = %v, want %q", name, fields["prompt"], template.Content)
+		}
+		if fields["description"] != "Review a design" {
+			t.Errorf("%s description = %v", name, fields["description"])
+		}
+		if fields["category"] != "review" {
+			t.Errorf("%s category = %v, unmapped metadata should be kept", name, fields["category"])
+		}
+	}
+}
+
+func TestMDFormatter_KeyOrder(t *testing.T) {
+	template := &resources.CommandTemplate{
+		Metadata: map[string]interface{}{"title": "Design", "description": "Gather requirements", "category": "Archie"},
+		Content:  "Body\n",
+		Keys:     []string{"title", "description"},
+	}
+	mapping := map[string]string{"title": "name", "content": "[CONTENT]"}
+
+	output, err := (&MDFormatter{}).Format(template, mapping)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	// Keys in template order (mapped ones renamed), then the unordered ones, then the body
+	want := "---\nname: Design\ndescription: Gather requirements\ncategory: Archie\n---\nBody\n"
+	if output != want {
+		t.Errorf("Format() = %q, want %q", output, want)
+	}
+}
+
+func TestGetFormat_Unknown(t *testing.T) {
+	_, err := GetFormat("docx")
+	if err == nil {
+		t.Fatal("GetFormat(docx) should fail")
+	}
+	if !strings.Contains(err.Error(), "mdc") {
+		t.Errorf("error should list the available formats, got %v", err)
+	}
+}
+
+func TestGetFormattedCommands_Extension(t *testing.T) {
+	tests := []struct {
+		name   string
+		config CustomAgentConfig
+		ext    string
+	}{
+		{
+			name:   "format default",
+			config: CustomAgentConfig{Name: "test-format-mdc", CommandsDir: ".rules", FileFormat: "mdc"},
+			ext:    ".mdc",
+		},
+		{
+			name:   "configured extension",
+			config: CustomAgentConfig{Name: "test-format-yml", CommandsDir: ".prompts", FileFormat: "yaml", FileExtension: "yml"},
+			ext:    ".yml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCustomAgentConfig(tt.config); err != nil {
+				t.Fatalf("ValidateCustomAgentConfig() error = %v", err)
+			}
+			Register(NewCustomAgent(tt.config))
+
+			commands, err := GetFormattedCommands(tt.config.Name)
+			if err != nil {
+				t.Fatalf("GetFormattedCommands() error = %v", err)
+			}
+			if len(commands) == 0 {
+				t.Fatal("GetFormattedCommands() returned no commands")
+			}
+			for filename := range commands {
+				if !strings.HasSuffix(filename, tt.ext) {
+					t.Errorf("filename %s should end with %s", filename, tt.ext)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCustomAgentConfig_Format(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CustomAgentConfig
+		wantErr bool
+	}{
+		{"json with default mapping", CustomAgentConfig{FileFormat: "json"}, false},
+		{"unknown format", CustomAgentConfig{FileFormat: "docx"}, true},
+		{"extension with dot prefix", CustomAgentConfig{FileFormat: "md", FileExtension: ".markdown"}, false},
+		{"extension with path", CustomAgentConfig{FileFormat: "md", FileExtension: "../md"}, true},
+		{"mapping without content", CustomAgentConfig{FileFormat: "yaml", Mapping: map[string]string{"description": "description"}}, true},
+		{"sub-agent mapping without content", CustomAgentConfig{FileFormat: "md", SubAgentMapping: map[string]string{"name": "name"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Name = "test-validate"
+			tt.config.CommandsDir = ".prompts"
+			err := ValidateCustomAgentConfig(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCustomAgentConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
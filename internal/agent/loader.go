@@ -9,6 +9,14 @@ import (
 //go:embed agents.json
 var builtinAgentsJSON []byte
 
+// officialNames 内置官方 agent 的名称，自定义 agent 不能使用
+var officialNames = make(map[string]bool)
+
+// IsOfficialName 检查名称是否属于内置官方 agent
+func IsOfficialName(name string) bool {
+	return officialNames[name]
+}
+
 // LoadBuiltinAgents 加载内置 agents
 func LoadBuiltinAgents() error {
 	var configs []CustomAgentConfig
@@ -24,6 +32,9 @@ func LoadBuiltinAgents() error {
 		if config.Mapping == nil {
 			config.Mapping = getDefaultMapping(config.FileFormat)
 		}
+		if config.Official {
+			officialNames[config.Name] = true
+		}
 		agent := NewCustomAgent(config)
 		Register(agent)
 	}
@@ -0,0 +1,245 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/GarrickZ2/archie/internal/agentdoc"
+)
+
+// InstalledFile 一个 agent 安装的文件，记录在 state.json 的 manifest 中
+type InstalledFile struct {
+	Path string `json:"path" yaml:"path"` // 解析文件名模板后的路径，相对项目或以 ~/ 开头
+	Hash string `json:"hash" yaml:"hash"` // 写入内容的 sha256
+}
+
+// hashContent 返回文件内容的 sha256
+func hashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// InstalledHashes 返回所有 agents 的 manifest 中记录的 path → hash，
+// 作为 SetupOptions.Installed 用于发现用户修改过的文件
+func (p *ProjectState) InstalledHashes() map[string]string {
+	hashes := make(map[string]string)
+	for _, a := range p.InitializedAgents {
+		for _, file := range a.Files {
+			hashes[filepath.Clean(file.Path)] = file.Hash
+		}
+	}
+	return hashes
+}
+
+// manifestPaths 返回 manifest 中的路径集合
+func manifestPaths(manifest []InstalledFile) map[string]bool {
+	paths := make(map[string]bool, len(manifest))
+	for _, file := range manifest {
+		paths[filepath.Clean(file.Path)] = true
+	}
+	return paths
+}
+
+// sharedPaths 返回除 agentName 以外其他 agents 的 manifest 中的路径
+// （例如 cursor 和 windsurf 共用的 AGENTS.md）
+func sharedPaths(state *ProjectState, agentName string) map[string]bool {
+	paths := make(map[string]bool)
+	for _, a := range state.InitializedAgents {
+		if a.AgentName == agentName {
+			continue
+		}
+		for path := range manifestPaths(a.Files) {
+			paths[path] = true
+		}
+	}
+	return paths
+}
+
+// removeFiles 删除文件（连同 <file>.new 副本）并清理因此变空的目录，跳过 keep 中的路径
+// 和已不存在的文件。背景文档中还有项目自己的内容时，只移除 Archie 的 managed block
+func (s *DefaultSetupper) removeFiles(projectPath string, paths []string, keep map[string]bool) ([]FileResult, error) {
+	sort.Strings(paths)
+	results := []FileResult{}
+
+	for _, relPath := range paths {
+		if keep[filepath.Clean(relPath)] {
+			continue
+		}
+
+		newCopies, err := s.removeNewCopy(projectPath, relPath)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, newCopies...)
+
+		fullPath, err := resolvePath(projectPath, relPath)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := afero.ReadFile(s.fs, fullPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", fullPath, err)
+		}
+
+		// 背景文档只删除 Archie 的 managed block，保留项目自己的内容
+		if _, ok := agentdoc.Extract(string(data)); ok {
+			if rest := agentdoc.Remove(string(data)); rest != "" {
+				if err := afero.WriteFile(s.fs, fullPath, []byte(rest), 0644); err != nil {
+					return nil, fmt.Errorf("failed to write file %s: %w", fullPath, err)
+				}
+				results = append(results, FileResult{Path: relPath, Change: FileUpdated})
+				continue
+			}
+		}
+
+		if err := s.fs.Remove(fullPath); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", fullPath, err)
+		}
+		results = append(results, FileResult{Path: relPath, Change: FileRemoved})
+
+		if err := s.pruneEmptyDirs(filepath.Dir(fullPath), pruneLimit(projectPath, relPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// removeNewCopy 删除 setup 写在已安装文件旁边的 <file>.new 副本（不存在时不做任何事）
+func (s *DefaultSetupper) removeNewCopy(projectPath, relPath string) ([]FileResult, error) {
+	fullPath, err := resolvePath(projectPath, relPath+".new")
+	if err != nil {
+		return nil, err
+	}
+	if exists, _ := afero.Exists(s.fs, fullPath); !exists {
+		return nil, nil
+	}
+	if err := s.fs.Remove(fullPath); err != nil {
+		return nil, fmt.Errorf("failed to remove %s: %w", fullPath, err)
+	}
+	if err := s.pruneEmptyDirs(filepath.Dir(fullPath), pruneLimit(projectPath, relPath)); err != nil {
+		return nil, err
+	}
+	return []FileResult{{Path: relPath + ".new", Change: FileRemoved}}, nil
+}
+
+// baseDir 保存上次安装的模板内容（按 hash 命名），用于三方合并
+func baseDir(projectPath string) string {
+	return filepath.Join(projectPath, ".archie", "base")
+}
+
+// saveBase 保存安装的模板内容，作为以后三方合并的共同祖先
+func (s *DefaultSetupper) saveBase(projectPath, content string) error {
+	path := filepath.Join(baseDir(projectPath), hashContent(content))
+	if exists, _ := afero.Exists(s.fs, path); exists {
+		return nil
+	}
+	if err := s.fs.MkdirAll(baseDir(projectPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", baseDir(projectPath), err)
+	}
+	if err := afero.WriteFile(s.fs, path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadBase 读取 hash 对应的模板内容，不存在时 ok 为 false
+func (s *DefaultSetupper) loadBase(projectPath, hash string) (content string, ok bool, err error) {
+	data, err := afero.ReadFile(s.fs, filepath.Join(baseDir(projectPath), hash))
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+// pruneBases 删除不再被任何 manifest 引用的模板内容
+func (s *DefaultSetupper) pruneBases(projectPath string, state *ProjectState) error {
+	referenced := make(map[string]bool)
+	for _, a := range state.InitializedAgents {
+		for _, file := range a.Files {
+			referenced[file.Hash] = true
+		}
+	}
+
+	entries, err := afero.ReadDir(s.fs, baseDir(projectPath))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || referenced[entry.Name()] {
+			continue
+		}
+		if err := s.fs.Remove(filepath.Join(baseDir(projectPath), entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// modified 判断已安装的文件是否被用户修改过（内容与 manifest 记录的 hash 不同，
+// 背景文档只比较 managed block）
+func (s *DefaultSetupper) modified(projectPath string, file InstalledFile) (bool, error) {
+	fullPath, err := resolvePath(projectPath, file.Path)
+	if err != nil {
+		return false, err
+	}
+	data, err := afero.ReadFile(s.fs, fullPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if block, ok := agentdoc.Extract(string(data)); ok {
+		return hashContent(block) != file.Hash, nil
+	}
+	return hashContent(string(data)) != file.Hash, nil
+}
+
+// pruneLimit 返回清理空目录时不能越过的目录（项目根目录或用户目录）
+func pruneLimit(projectPath, relPath string) string {
+	if strings.HasPrefix(relPath, "~/") {
+		if home, err := expandPath("~/"); err == nil {
+			return home
+		}
+	}
+	return projectPath
+}
+
+// pruneEmptyDirs 从 dir 向上删除空目录，直到 limit（不含）
+func (s *DefaultSetupper) pruneEmptyDirs(dir, limit string) error {
+	limit = filepath.Clean(limit)
+	for dir = filepath.Clean(dir); dir != limit && strings.HasPrefix(dir, limit+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		entries, err := afero.ReadDir(s.fs, dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if len(entries) > 0 {
+			return nil
+		}
+		if err := s.fs.Remove(dir); err != nil {
+			return fmt.Errorf("failed to remove directory %s: %w", dir, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package agent
+
+import "strings"
+
+// 冲突标记，与 git 的格式一致
+const (
+	conflictStart = "<<<<<<< yours\n"
+	conflictSep   = "=======\n"
+	conflictEnd   = ">>>>>>> archie\n"
+)
+
+// merge3 以 base（上次安装的模板）为共同祖先，按行合并 ours（用户修改后的文件）
+// 和 theirs（新模板）。两边改了同一处时写入冲突标记，clean 为 false
+func merge3(base, ours, theirs string) (merged string, clean bool) {
+	baseLines, ourLines, theirLines := splitLines(base), splitLines(ours), splitLines(theirs)
+	ourMatch := matchLines(baseLines, ourLines)
+	theirMatch := matchLines(baseLines, theirLines)
+
+	var out strings.Builder
+	clean = true
+	i, j, k := 0, 0, 0
+	for {
+		// base 中的行两边都未改动
+		if i < len(baseLines) && ourMatch[i] == j && theirMatch[i] == k {
+			out.WriteString(baseLines[i])
+			i, j, k = i+1, j+1, k+1
+			continue
+		}
+
+		// 找到下一个两边都保留的 base 行，之前的部分是一个改动块
+		next := i
+		for next < len(baseLines) && (ourMatch[next] < 0 || theirMatch[next] < 0) {
+			next++
+		}
+		ourEnd, theirEnd := len(ourLines), len(theirLines)
+		if next < len(baseLines) {
+			ourEnd, theirEnd = ourMatch[next], theirMatch[next]
+		}
+
+		baseChunk := baseLines[i:next]
+		ourChunk := ourLines[j:ourEnd]
+		theirChunk := theirLines[k:theirEnd]
+		switch {
+		case equalLines(ourChunk, baseChunk):
+			writeLines(&out, theirChunk, false)
+		case equalLines(theirChunk, baseChunk), equalLines(ourChunk, theirChunk):
+			writeLines(&out, ourChunk, false)
+		default:
+			clean = false
+			out.WriteString(conflictStart)
+			writeLines(&out, ourChunk, true)
+			out.WriteString(conflictSep)
+			writeLines(&out, theirChunk, true)
+			out.WriteString(conflictEnd)
+		}
+
+		if next == len(baseLines) {
+			break
+		}
+		i, j, k = next, ourEnd, theirEnd
+	}
+
+	return out.String(), clean
+}
+
+// splitLines 按行切分，每行保留换行符
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matchLines 用最长公共子序列对齐 a 和 b，返回 a 中每行在 b 中的下标（未匹配为 -1）
+func matchLines(a, b []string) []int {
+	// lcs[i][j] 是 a[i:] 与 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			match[i] = j
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
+
+// equalLines 比较两段行是否相同
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// writeLines 写入一段行；terminate 时补上缺失的末尾换行（冲突标记必须独占一行）
+func writeLines(out *strings.Builder, lines []string, terminate bool) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+	if terminate && len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		out.WriteString("\n")
+	}
+}
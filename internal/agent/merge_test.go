@@ -0,0 +1,64 @@
+package agent
+
+import "testing"
+
+func TestMerge3(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   string
+		ours   string
+		theirs string
+		want   string
+		clean  bool
+	}{
+		{
+			name:   "edits in different places",
+			base:   "a\nb\nc\nd\n",
+			ours:   "a\nb\nc\nd\nmy note\n",
+			theirs: "A\nb\nc\nd\n",
+			want:   "A\nb\nc\nd\nmy note\n",
+			clean:  true,
+		},
+		{
+			name:   "same edit on both sides",
+			base:   "a\nb\n",
+			ours:   "a\nB\n",
+			theirs: "a\nB\n",
+			want:   "a\nB\n",
+			clean:  true,
+		},
+		{
+			name:   "template line removed",
+			base:   "a\nb\nc\n",
+			ours:   "x\na\nb\nc\n",
+			theirs: "a\nc\n",
+			want:   "x\na\nc\n",
+			clean:  true,
+		},
+		{
+			name:   "conflict",
+			base:   "a\nb\nc\n",
+			ours:   "a\nmine\nc\n",
+			theirs: "a\ntheirs\nc\n",
+			want:   "a\n<<<<<<< yours\nmine\n=======\ntheirs\n>>>>>>> archie\nc\n",
+			clean:  false,
+		},
+		{
+			name:   "no trailing newline",
+			base:   "a\nb",
+			ours:   "a\nb",
+			theirs: "a\nc",
+			want:   "a\nc",
+			clean:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, clean := merge3(tt.base, tt.ours, tt.theirs)
+			if got != tt.want || clean != tt.clean {
+				t.Errorf("merge3() = %q, %v, want %q, %v", got, clean, tt.want, tt.clean)
+			}
+		})
+	}
+}
@@ -2,13 +2,17 @@ package agent
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/afero"
 
+	"github.com/GarrickZ2/archie/internal/agentdoc"
 	"github.com/GarrickZ2/archie/resources"
 )
 
@@ -48,78 +52,308 @@ func resolvePath(basePath, relPath string) (string, error) {
 	return filepath.Join(basePath, relPath), nil
 }
 
+// AgentDoc returns the background doc an agent reads (e.g. CLAUDE.md or AGENTS.md)
+func AgentDoc(agent Agent) string {
+	if customAgent, ok := agent.(*CustomAgent); ok {
+		return customAgent.config.AgentDoc
+	}
+	// For non-CustomAgent, default to AGENTS.md
+	return "AGENTS.md"
+}
+
+// FileChange describes what setup did to one file
+type FileChange string
+
+const (
+	FileAdded     FileChange = "added"
+	FileUpdated   FileChange = "updated"
+	FileUnchanged FileChange = "unchanged"
+	FileRemoved   FileChange = "removed"
+	FileKept      FileChange = "kept"     // modified by the user and left as is
+	FileNewCopy   FileChange = "new copy" // modified by the user; the template was written to <path>.new
+	FileMerged    FileChange = "merged"   // user edits merged with the new template
+	FileConflict  FileChange = "conflict" // merged with conflict markers to resolve by hand
+)
+
+// Resolution is what setup does with a file the user modified since it was installed
+type Resolution string
+
+const (
+	ResolveKeep      Resolution = "keep"      // leave the user's file untouched
+	ResolveOverwrite Resolution = "overwrite" // replace it with the new template
+	ResolveNewCopy   Resolution = "new"       // write the new template next to it as <path>.new
+	ResolveMerge     Resolution = "merge"     // three-way merge against the previously installed template
+)
+
+// ModifiedFile is a user-modified file setup is about to replace
+type ModifiedFile struct {
+	Path     string // as configured, e.g. .claude/commands/archie-design.md
+	CanMerge bool   // the previously installed template is available for a three-way merge
+}
+
+// ModifiedHandler chooses the resolution for a user-modified file
+type ModifiedHandler func(file ModifiedFile) (Resolution, error)
+
+// FileResult is the change made to one installed file
+type FileResult struct {
+	Path   string     `json:"path" yaml:"path"` // as configured, e.g. .claude/commands/archie-design.md
+	Change FileChange `json:"change" yaml:"change"`
+}
+
+// SetupReport lists the files Setup wrote, the hash of the installed templates
+// and the manifest of every file the agent owns
+type SetupReport struct {
+	Files        []FileResult
+	TemplateHash string
+	Manifest     []InstalledFile
+}
+
+// installFile is one file an agent installs
+type installFile struct {
+	relPath  string // relative to the project, or ~/... for global folders
+	content  string // for the agent doc, the managed block only
+	agentDoc bool
+}
+
+// installFiles returns the files an agent installs in a project with the given options,
+// sorted by path. Command paths are resolved from the agent's filename template.
+func installFiles(agent Agent, projectPath string, options SetupOptions) ([]installFile, error) {
+	var files []installFile
+	pathConfig := agent.PathConfig()
+
+	// 1. Agent doc (AGENTS.md or custom doc) if specified; Archie owns only its managed block
+	if agentDoc := AgentDoc(agent); agentDoc != "" {
+		files = append(files, installFile{relPath: agentDoc, content: agentdoc.Block(resources.AgentsMdContent), agentDoc: true})
+	}
+
+	// 2. Commands in {commands_dir}/ (only the enabled ones when EnabledCommands is set)
+	enabled, err := ResolveCommandNames(options.EnabledCommands)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, project := filenameTemplate(agent), projectName(projectPath)
+	for filename, content := range agent.Commands() {
+		if commandEnabled(filename, enabled) {
+			relPath := filepath.Join(pathConfig.CommandsDir, commandFilename(tmpl, project, filename))
+			files = append(files, installFile{relPath: relPath, content: content})
+		}
+	}
+
+	// 3. Sub-agents in {sub_agents_dir}/ (if supported)
+	if agent.SupportsSubAgents() {
+		for filename, content := range agent.SubAgents() {
+			files = append(files, installFile{relPath: filepath.Join(pathConfig.SubAgentsDir, filename), content: content})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].relPath < files[j].relPath
+	})
+	return files, nil
+}
+
+// hashFiles hashes the paths and contents of installed files
+func hashFiles(files []installFile) string {
+	hash := sha256.New()
+	for _, file := range files {
+		hash.Write([]byte(file.relPath))
+		hash.Write([]byte{0})
+		hash.Write([]byte(file.content))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// TemplateHash returns the hash of the files an agent installs in a project with the
+// given options. It changes when a new Archie version ships different prompts.
+func TemplateHash(projectPath, agentName string, options SetupOptions) (string, error) {
+	agent, err := Get(agentName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get agent: %w", err)
+	}
+	files, err := installFiles(agent, projectPath, options)
+	if err != nil {
+		return "", err
+	}
+	return hashFiles(files), nil
+}
+
 // Setup sets up agent configuration in the project
 func (s *DefaultSetupper) Setup(ctx context.Context, config SetupConfig) error {
+	_, err := s.SetupWithReport(ctx, config)
+	return err
+}
+
+// SetupWithReport sets up agent configuration and reports the change made to each file
+func (s *DefaultSetupper) SetupWithReport(ctx context.Context, config SetupConfig) (*SetupReport, error) {
 	// Get agent
 	agent, err := Get(config.AgentType)
 	if err != nil {
-		return fmt.Errorf("failed to get agent: %w", err)
+		return nil, fmt.Errorf("failed to get agent: %w", err)
 	}
 
-	pathConfig := agent.PathConfig()
-
-	// 1. Get agent configuration (for background doc)
-	var agentDoc string
-	if customAgent, ok := agent.(*CustomAgent); ok {
-		agentDoc = customAgent.config.AgentDoc
-	} else {
-		// For non-CustomAgent, default to AGENTS.md
-		agentDoc = "AGENTS.md"
+	files, err := installFiles(agent, config.ProjectPath, config.Options)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. Force overwrite agent doc (AGENTS.md or custom doc) if specified
-	if agentDoc != "" {
-		agentDocPath := filepath.Join(config.ProjectPath, agentDoc)
-		if err = s.writeFile(agentDocPath, resources.AgentsMdContent); err != nil {
-			return fmt.Errorf("failed to sync to %s: %w", agentDoc, err)
+	report := &SetupReport{TemplateHash: hashFiles(files)}
+	for _, file := range files {
+		entry := InstalledFile{Path: file.relPath, Hash: hashContent(file.content)}
+
+		// Agent doc shared with an agent set up earlier in the same run
+		if file.agentDoc && config.Options.SkipAgentDoc {
+			if hash, ok := config.Options.Installed[filepath.Clean(file.relPath)]; ok {
+				entry.Hash = hash
+			}
+			report.Manifest = append(report.Manifest, entry)
+			continue
+		}
+
+		fullPath, err := resolvePath(config.ProjectPath, file.relPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path for %s: %w", file.relPath, err)
+		}
+
+		change, hash, err := s.installFile(config.ProjectPath, fullPath, file, config.Options)
+		if err != nil {
+			return nil, err
+		}
+		entry.Hash = hash
+		report.Manifest = append(report.Manifest, entry)
+		report.Files = append(report.Files, FileResult{Path: file.relPath, Change: change})
+
+		if config.Options.Installed != nil {
+			config.Options.Installed[filepath.Clean(file.relPath)] = hash
 		}
 	}
 
-	// 3. Install commands to {commands_dir}/
-	commands := agent.Commands()
-	for filename, content := range commands {
-		relPath := filepath.Join(pathConfig.CommandsDir, filename)
-		fullPath, err := resolvePath(config.ProjectPath, relPath)
+	return report, nil
+}
+
+// installFile writes one file and returns the change and the hash to record in the
+// manifest. A file whose content differs from the hash recorded at install time was
+// edited by the user and is only replaced as the OnModified handler decides. So is an
+// existing file with no recorded hash (an install older than the manifest) that
+// differs from the template.
+// In the agent doc only the managed block is compared and replaced.
+func (s *DefaultSetupper) installFile(projectPath, fullPath string, file installFile, options SetupOptions) (FileChange, string, error) {
+	templateHash := hashContent(file.content)
+
+	data, err := afero.ReadFile(s.fs, fullPath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", "", fmt.Errorf("failed to read %s: %w", fullPath, err)
+	}
+	exists := err == nil
+
+	// The part of the file Archie owns, and the whole file with that part replaced
+	existing, hasExisting := string(data), exists
+	render := func(content string) string { return content }
+	if file.agentDoc {
+		existing, hasExisting = agentdoc.Extract(string(data))
+		render = func(block string) string { return agentdoc.Merge(string(data), block) }
+	}
+
+	recorded, ok := options.Installed[filepath.Clean(file.relPath)]
+	if !hasExisting || options.Force || existing == file.content || (ok && hashContent(existing) == recorded) {
+		change, err := s.writeFile(fullPath, render(file.content))
 		if err != nil {
-			return fmt.Errorf("failed to resolve path for command %s: %w", filename, err)
+			return "", "", err
 		}
+		return change, templateHash, s.saveBase(projectPath, file.content)
+	}
 
-		if err = s.writeFile(fullPath, content); err != nil {
-			return err
+	var base string
+	var hasBase bool
+	if ok {
+		// Edited by the user, but Archie ships the same template as last time: nothing to resolve
+		if templateHash == recorded {
+			return FileKept, recorded, nil
+		}
+		if base, hasBase, err = s.loadBase(projectPath, recorded); err != nil {
+			return "", "", err
 		}
+	} else {
+		// Installed before Archie kept a manifest (or written by the user): the file differs
+		// from the template, so treat it as an edit of the current template
+		recorded = templateHash
 	}
 
-	// 4. Install sub-agents to {sub_agents_dir}/ (if supported)
-	if agent.SupportsSubAgents() {
-		subAgents := agent.SubAgents()
-		for filename, content := range subAgents {
-			relPath := filepath.Join(pathConfig.SubAgentsDir, filename)
-			fullPath, err := resolvePath(config.ProjectPath, relPath)
-			if err != nil {
-				return fmt.Errorf("failed to resolve path for sub-agent %s: %w", filename, err)
-			}
+	resolution := ResolveNewCopy
+	if options.OnModified != nil {
+		resolution, err = options.OnModified(ModifiedFile{Path: file.relPath, CanMerge: hasBase})
+		if err != nil {
+			return "", "", err
+		}
+	}
 
-			if err = s.writeFile(fullPath, content); err != nil {
-				return err
-			}
+	switch resolution {
+	case ResolveKeep:
+		return FileKept, recorded, nil
+	case ResolveOverwrite:
+		if _, err := s.writeFile(fullPath, render(file.content)); err != nil {
+			return "", "", err
+		}
+		return FileUpdated, templateHash, s.saveBase(projectPath, file.content)
+	case ResolveNewCopy:
+		if _, err := s.writeFile(fullPath+".new", render(file.content)); err != nil {
+			return "", "", err
 		}
+		return FileNewCopy, recorded, nil
+	case ResolveMerge:
+		if !hasBase {
+			return "", "", fmt.Errorf("cannot merge %s: the previously installed template is not available", file.relPath)
+		}
+		merged, clean := merge3(base, existing, file.content)
+		if _, err := s.writeFile(fullPath, render(merged)); err != nil {
+			return "", "", err
+		}
+		change := FileMerged
+		if !clean {
+			change = FileConflict
+		}
+		// The new template is the base of the next merge
+		return change, templateHash, s.saveBase(projectPath, file.content)
+	default:
+		return "", "", fmt.Errorf("unknown resolution %q for %s", resolution, file.relPath)
 	}
+}
 
-	return nil
+// commandEnabled reports whether a command file is in the enabled list (empty list enables all)
+func commandEnabled(filename string, enabled []string) bool {
+	if len(enabled) == 0 {
+		return true
+	}
+	name := strings.TrimSuffix(filename, filepath.Ext(filename))
+	for _, e := range enabled {
+		if e == name {
+			return true
+		}
+	}
+	return false
 }
 
-// writeFile writes a single file
-func (s *DefaultSetupper) writeFile(fullPath, content string) error {
+// writeFile writes a single file, leaving it untouched when the content is the same
+func (s *DefaultSetupper) writeFile(fullPath, content string) (FileChange, error) {
+	change := FileAdded
+	existing, err := afero.ReadFile(s.fs, fullPath)
+	if err == nil {
+		if string(existing) == content {
+			return FileUnchanged, nil
+		}
+		change = FileUpdated
+	}
+
 	// Create parent directory
 	dir := filepath.Dir(fullPath)
 	if err := s.fs.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
 
 	// Write file
 	if err := afero.WriteFile(s.fs, fullPath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", fullPath, err)
+		return "", fmt.Errorf("failed to write file %s: %w", fullPath, err)
 	}
 
-	return nil
+	return change, nil
 }
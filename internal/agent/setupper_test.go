@@ -1,10 +1,15 @@
 package agent
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/GarrickZ2/archie/internal/agentdoc"
 )
 
 func TestExpandPath(t *testing.T) {
@@ -146,3 +151,196 @@ func TestResolvePathIntegration(t *testing.T) {
 		t.Errorf("resolvePath() for tilde path should not contain project path, got %v", fullPath)
 	}
 }
+
+func TestSetup_EnabledCommands(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	setupper := NewSetupper(fs)
+
+	err := setupper.Setup(context.Background(), SetupConfig{
+		ProjectPath: "/project",
+		AgentType:   "claude-code",
+		Options:     SetupOptions{EnabledCommands: []string{"design", "archie-spec"}},
+	})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	entries, err := afero.ReadDir(fs, "/project/.claude/commands")
+	if err != nil {
+		t.Fatalf("failed to read commands dir: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != "archie-design.md,archie-spec.md" {
+		t.Errorf("installed commands = %v, want [archie-design.md archie-spec.md]", names)
+	}
+
+	err = setupper.Setup(context.Background(), SetupConfig{
+		ProjectPath: "/project",
+		AgentType:   "claude-code",
+		Options:     SetupOptions{EnabledCommands: []string{"desing"}},
+	})
+	if err == nil {
+		t.Error("Setup() with an unknown command should fail")
+	}
+}
+
+func TestSetup_SkipAgentDoc(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	setupper := NewSetupper(fs)
+
+	err := setupper.Setup(context.Background(), SetupConfig{
+		ProjectPath: "/project",
+		AgentType:   "cursor",
+		Options:     SetupOptions{SkipAgentDoc: true},
+	})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if exists, _ := afero.Exists(fs, "/project/AGENTS.md"); exists {
+		t.Error("AGENTS.md should not be written when SkipAgentDoc is set")
+	}
+}
+
+func TestSetup_ModifiedFiles(t *testing.T) {
+	const designPath = ".claude/commands/archie-design.md"
+	fs := afero.NewMemMapFs()
+	setupper := NewSetupper(fs)
+	installed := make(map[string]string)
+
+	setup := func(options SetupOptions) *SetupReport {
+		t.Helper()
+		options.EnabledCommands = []string{"design"}
+		options.Installed = installed
+		report, err := setupper.SetupWithReport(context.Background(), SetupConfig{
+			ProjectPath: "/project",
+			AgentType:   "claude-code",
+			Options:     options,
+		})
+		if err != nil {
+			t.Fatalf("SetupWithReport() error = %v", err)
+		}
+		return report
+	}
+	change := func(report *SetupReport) FileChange {
+		for _, file := range report.Files {
+			if file.Path == designPath {
+				return file.Change
+			}
+		}
+		return ""
+	}
+
+	setup(SetupOptions{})
+	template, err := afero.ReadFile(fs, "/project/"+designPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate an install of an older template that the user then edited
+	oldTemplate := "old first line\n" + string(template)
+	if err := afero.WriteFile(fs, "/project/.archie/base/"+hashContent(oldTemplate), []byte(oldTemplate), 0644); err != nil {
+		t.Fatal(err)
+	}
+	edited := oldTemplate + "my note\n"
+	reset := func() {
+		t.Helper()
+		installed[designPath] = hashContent(oldTemplate)
+		if err := afero.WriteFile(fs, "/project/"+designPath, []byte(edited), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Without a handler the new template is written next to the edited file
+	reset()
+	if got := change(setup(SetupOptions{})); got != FileNewCopy {
+		t.Errorf("change = %q, want %q", got, FileNewCopy)
+	}
+	if data, _ := afero.ReadFile(fs, "/project/"+designPath); string(data) != edited {
+		t.Error("edited file was overwritten")
+	}
+	if data, _ := afero.ReadFile(fs, "/project/"+designPath+".new"); string(data) != string(template) {
+		t.Error(".new copy does not contain the new template")
+	}
+	if installed[designPath] != hashContent(oldTemplate) {
+		t.Error("the recorded hash of a file that was not replaced must not change")
+	}
+
+	reset()
+	var asked ModifiedFile
+	report := setup(SetupOptions{OnModified: func(file ModifiedFile) (Resolution, error) {
+		asked = file
+		return ResolveMerge, nil
+	}})
+	if asked.Path != designPath || !asked.CanMerge {
+		t.Errorf("handler got %+v, want %s with CanMerge", asked, designPath)
+	}
+	if got := change(report); got != FileMerged {
+		t.Errorf("change = %q, want %q", got, FileMerged)
+	}
+	if data, _ := afero.ReadFile(fs, "/project/"+designPath); string(data) != string(template)+"my note\n" {
+		t.Errorf("merged file = %q, want the new template with the user's note", data)
+	}
+
+	reset()
+	if got := change(setup(SetupOptions{OnModified: func(ModifiedFile) (Resolution, error) {
+		return ResolveKeep, nil
+	}})); got != FileKept {
+		t.Errorf("change = %q, want %q", got, FileKept)
+	}
+
+	reset()
+	if got := change(setup(SetupOptions{Force: true})); got != FileUpdated {
+		t.Errorf("change = %q, want %q", got, FileUpdated)
+	}
+	if installed[designPath] != hashContent(string(template)) {
+		t.Error("an overwritten file must record the new template hash")
+	}
+}
+
+func TestSetup_AgentDocManagedBlock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/project/CLAUDE.md", []byte("# Team rules\nUse tabs.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	state := NewStateManager(fs)
+	if err := state.MarkInitialized("/project", AgentState{AgentName: "claude-code", EnabledCommands: []string{"design"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	syncer := NewSyncer(fs)
+	if _, err := syncer.Sync(context.Background(), "/project"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	data, _ := afero.ReadFile(fs, "/project/CLAUDE.md")
+	if !strings.HasPrefix(string(data), "# Team rules\nUse tabs.\n\n"+agentdoc.Begin+"\n") {
+		t.Errorf("CLAUDE.md = %q, want the team rules followed by the Archie block", data)
+	}
+
+	// Edits outside the block are not user modifications of an Archie file
+	if err := afero.WriteFile(fs, "/project/CLAUDE.md", append(data, "\n## More rules\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := syncer.Sync(context.Background(), "/project")
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, file := range result.Agents[0].Files {
+		if file.Path == "CLAUDE.md" && file.Change != FileUnchanged {
+			t.Errorf("CLAUDE.md change = %q, want unchanged", file.Change)
+		}
+	}
+
+	// Removing the agent only takes out the block
+	if _, err := syncer.Remove("/project", "claude-code"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	data, _ = afero.ReadFile(fs, "/project/CLAUDE.md")
+	if string(data) != "# Team rules\nUse tabs.\n\n## More rules\n" {
+		t.Errorf("CLAUDE.md after remove = %q", data)
+	}
+}
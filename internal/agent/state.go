@@ -12,9 +12,13 @@ import (
 
 // AgentState 记录 agent 的初始化状态
 type AgentState struct {
-	AgentName     string    `json:"agent_name"`
-	IsCustom      bool      `json:"is_custom"`
-	InitializedAt time.Time `json:"initialized_at"`
+	AgentName       string          `json:"agent_name"`
+	IsCustom        bool            `json:"is_custom"`
+	InitializedAt   time.Time       `json:"initialized_at"`
+	ArchieVersion   string          `json:"archie_version,omitempty"`   // 安装时的 Archie 版本
+	TemplateHash    string          `json:"template_hash,omitempty"`    // 安装的文件内容的 hash，见 TemplateHash
+	EnabledCommands []string        `json:"enabled_commands,omitempty"` // 安装时启用的命令（空则全部）
+	Files           []InstalledFile `json:"files,omitempty"`            // 安装的文件（manifest），用于卸载和清理
 }
 
 // ProjectState 项目状态
@@ -87,28 +91,34 @@ func (m *StateManager) Save(projectPath string, state *ProjectState) error {
 	return nil
 }
 
-// MarkInitialized 标记 agent 为已初始化
-func (m *StateManager) MarkInitialized(projectPath string, agentName string, isCustom bool) error {
+// MarkInitialized 标记一个或多个 agent 为已初始化
+// 所有 agent 在一次读写中记录，要么全部写入，要么都不写入
+func (m *StateManager) MarkInitialized(projectPath string, agents ...AgentState) error {
 	state, err := m.Load(projectPath)
 	if err != nil {
 		return err
 	}
 
-	// 检查是否已存在
-	for i, a := range state.InitializedAgents {
-		if a.AgentName == agentName {
-			// 更新时间戳
-			state.InitializedAgents[i].InitializedAt = time.Now()
-			return m.Save(projectPath, state)
+	now := time.Now()
+	for _, agent := range agents {
+		agent.InitializedAt = now
+
+		found := false
+		for i, a := range state.InitializedAgents {
+			if a.AgentName == agent.AgentName {
+				// 更新记录（时间戳、版本和模板 hash）
+				state.InitializedAgents[i] = agent
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
 		}
-	}
 
-	// 添加新记录
-	state.InitializedAgents = append(state.InitializedAgents, AgentState{
-		AgentName:     agentName,
-		IsCustom:      isCustom,
-		InitializedAt: time.Now(),
-	})
+		// 添加新记录
+		state.InitializedAgents = append(state.InitializedAgents, agent)
+	}
 
 	return m.Save(projectPath, state)
 }
@@ -184,6 +194,9 @@ func GetAllAgents(projectPath string) ([]AgentInfo, error) {
 		displayName := name
 		if isCustom {
 			displayName = fmt.Sprintf("%s (custom)", name)
+			if ca := agent.(*CustomAgent); ca.Scope() == ScopeProject {
+				displayName = fmt.Sprintf("%s (project)", name)
+			}
 		}
 
 		infos = append(infos, AgentInfo{
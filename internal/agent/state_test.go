@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestStateManager_MarkInitialized_Multiple(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manager := NewStateManager(fs)
+
+	if err := manager.MarkInitialized("/project", AgentState{AgentName: "claude-code"}); err != nil {
+		t.Fatalf("MarkInitialized() error = %v", err)
+	}
+	err := manager.MarkInitialized("/project",
+		AgentState{AgentName: "claude-code"},
+		AgentState{AgentName: "cursor"},
+		AgentState{AgentName: "my-agent", IsCustom: true},
+	)
+	if err != nil {
+		t.Fatalf("MarkInitialized() error = %v", err)
+	}
+
+	agents, err := manager.GetInitializedAgents("/project")
+	if err != nil {
+		t.Fatalf("GetInitializedAgents() error = %v", err)
+	}
+	if len(agents) != 3 {
+		t.Fatalf("got %d agents, want 3 (claude-code must not be duplicated)", len(agents))
+	}
+	if !agents[2].IsCustom || agents[2].InitializedAt.IsZero() {
+		t.Errorf("custom agent recorded as %+v", agents[2])
+	}
+}
@@ -0,0 +1,333 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+
+	"github.com/GarrickZ2/archie/internal/version"
+	"github.com/GarrickZ2/archie/resources"
+)
+
+// AgentSyncResult 一个 agent 的同步结果
+type AgentSyncResult struct {
+	Agent   string       `json:"agent" yaml:"agent"`
+	Files   []FileResult `json:"files" yaml:"files"`
+	Skipped string       `json:"skipped,omitempty" yaml:"skipped,omitempty"` // 未同步的原因
+}
+
+// SyncResult archie sync 的结果
+type SyncResult struct {
+	Agents []AgentSyncResult `json:"agents" yaml:"agents"`
+	Docs   []FileResult      `json:"docs" yaml:"docs"` // .archie/docs 下的文件
+}
+
+// Counts 按变化类型统计文件数
+func (r *SyncResult) Counts() map[FileChange]int {
+	counts := make(map[FileChange]int)
+	for _, agent := range r.Agents {
+		for _, file := range agent.Files {
+			counts[file.Change]++
+		}
+	}
+	for _, file := range r.Docs {
+		counts[file.Change]++
+	}
+	return counts
+}
+
+// OutdatedAgent 安装的文件与当前 Archie 模板不一致的 agent
+type OutdatedAgent struct {
+	Name             string `json:"name" yaml:"name"`
+	InstalledVersion string `json:"installed_version" yaml:"installed_version"` // 空表示安装时未记录
+}
+
+// Syncer 按 .archie/state.json 重新安装已初始化的 agents，并刷新 .archie/docs
+type Syncer struct {
+	fs         afero.Fs
+	setupper   *DefaultSetupper
+	state      *StateManager
+	force      bool
+	onModified ModifiedHandler
+}
+
+// NewSyncer 创建同步器
+func NewSyncer(fs afero.Fs) *Syncer {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &Syncer{
+		fs:       fs,
+		setupper: &DefaultSetupper{fs: fs},
+		state:    NewStateManager(fs),
+	}
+}
+
+// SetForce 设置是否直接覆盖（和删除）用户修改过的文件
+func (s *Syncer) SetForce(force bool) {
+	s.force = force
+}
+
+// SetModifiedHandler 设置用户修改过的文件的处理方式（未设置时写入 .new 副本）
+func (s *Syncer) SetModifiedHandler(handler ModifiedHandler) {
+	s.onModified = handler
+}
+
+// Sync 重新安装每个已初始化的 agent（使用安装时启用的命令），删除 manifest 中
+// 已不再生成的文件，刷新 .archie/docs，并在 state.json 中记录当前的 Archie 版本、
+// 模板 hash 和新的 manifest
+func (s *Syncer) Sync(ctx context.Context, projectPath string) (*SyncResult, error) {
+	state, err := s.state.Load(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{Agents: []AgentSyncResult{}}
+	writtenDocs := make(map[string]bool)
+	installed := state.InstalledHashes()
+
+	for i, agentState := range state.InitializedAgents {
+		agentResult := AgentSyncResult{Agent: agentState.AgentName, Files: []FileResult{}}
+
+		agent, err := Get(agentState.AgentName)
+		if err != nil {
+			agentResult.Skipped = "agent is not registered (custom agent removed from the agent store?)"
+			result.Agents = append(result.Agents, agentResult)
+			continue
+		}
+
+		doc := filepath.Clean(AgentDoc(agent))
+		report, err := s.setupper.SetupWithReport(ctx, SetupConfig{
+			ProjectPath: projectPath,
+			AgentType:   agentState.AgentName,
+			Options: SetupOptions{
+				IncludeExamples: true,
+				EnabledCommands: agentState.EnabledCommands,
+				SkipAgentDoc:    writtenDocs[doc],
+				Installed:       installed,
+				Force:           s.force,
+				OnModified:      s.onModified,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writtenDocs[doc] = true
+
+		// Files installed before but no longer produced (renamed or removed commands)
+		// Files the user modified are kept and stay in the manifest
+		manifest := report.Manifest
+		current := manifestPaths(manifest)
+		var orphans []string
+		var kept []FileResult
+		for _, file := range agentState.Files {
+			if current[filepath.Clean(file.Path)] {
+				continue
+			}
+			modified, err := s.setupper.modified(projectPath, file)
+			if err != nil {
+				return nil, err
+			}
+			if modified && !s.force {
+				kept = append(kept, FileResult{Path: file.Path, Change: FileKept})
+				manifest = append(manifest, file)
+				continue
+			}
+			orphans = append(orphans, file.Path)
+		}
+		removed, err := s.setupper.removeFiles(projectPath, orphans, sharedPaths(state, agentState.AgentName))
+		if err != nil {
+			return nil, err
+		}
+
+		agentResult.Files = append(append(report.Files, kept...), removed...)
+		result.Agents = append(result.Agents, agentResult)
+
+		state.InitializedAgents[i].ArchieVersion = version.Get()
+		state.InitializedAgents[i].TemplateHash = report.TemplateHash
+		state.InitializedAgents[i].Files = manifest
+	}
+
+	if result.Docs, err = s.syncDocs(projectPath); err != nil {
+		return nil, err
+	}
+
+	if len(state.InitializedAgents) > 0 {
+		if err := s.state.Save(projectPath, state); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.setupper.pruneBases(projectPath, state); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result.Agents, func(i, j int) bool {
+		return result.Agents[i].Agent < result.Agents[j].Agent
+	})
+	return result, nil
+}
+
+// syncDocs 将内置文档写入 .archie/docs，并删除已不再内置的文件
+func (s *Syncer) syncDocs(projectPath string) ([]FileResult, error) {
+	docs, err := resources.DocFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	docsDir := filepath.Join(projectPath, ".archie", "docs")
+	results := []FileResult{}
+
+	names := make([]string, 0, len(docs))
+	for name := range docs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		change, err := s.setupper.writeFile(filepath.Join(docsDir, name), string(docs[name]))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, FileResult{Path: filepath.Join(".archie", "docs", name), Change: change})
+	}
+
+	// .archie/docs 完全由 Archie 管理，旧版本留下的文件直接删除
+	var removed []string
+	err = afero.Walk(s.fs, docsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(docsDir, path)
+		if err != nil {
+			return err
+		}
+		if _, ok := docs[relPath]; !ok {
+			removed = append(removed, relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range removed {
+		if err := s.fs.Remove(filepath.Join(docsDir, name)); err != nil {
+			return nil, err
+		}
+		results = append(results, FileResult{Path: filepath.Join(".archie", "docs", name), Change: FileRemoved})
+	}
+
+	return results, nil
+}
+
+// Remove 删除 agent 安装的文件（按 manifest）和它们的 .new 副本，清理空目录，并从 state.json
+// 中移除该 agent。其他 agent 也安装的文件（例如共用的 AGENTS.md）会保留；用户修改过的文件
+// 除非设置了 force，也会保留并报告为 FileKept
+func (s *Syncer) Remove(projectPath, agentName string) ([]FileResult, error) {
+	state, err := s.state.Load(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i, a := range state.InitializedAgents {
+		if a.AgentName == agentName {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("agent '%s' is not initialized in this project", agentName)
+	}
+	agentState := state.InitializedAgents[index]
+
+	// 没有 manifest 的旧安装：按当前模板会安装的文件处理，内容与模板不同的视为用户修改过
+	files := agentState.Files
+	if len(files) == 0 {
+		if agent, err := Get(agentName); err == nil {
+			templates, err := installFiles(agent, projectPath, SetupOptions{EnabledCommands: agentState.EnabledCommands})
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range templates {
+				files = append(files, InstalledFile{Path: file.relPath, Hash: hashContent(file.content)})
+			}
+		}
+	}
+
+	shared := sharedPaths(state, agentName)
+	paths := make([]string, 0, len(files))
+	var kept []FileResult
+	for _, file := range files {
+		if shared[filepath.Clean(file.Path)] {
+			continue
+		}
+		modified, err := s.setupper.modified(projectPath, file)
+		if err != nil {
+			return nil, err
+		}
+		if modified && !s.force {
+			// 用户的文件保留，但 Archie 写的 .new 副本已没有意义
+			newCopies, err := s.setupper.removeNewCopy(projectPath, file.Path)
+			if err != nil {
+				return nil, err
+			}
+			kept = append(kept, newCopies...)
+			kept = append(kept, FileResult{Path: file.Path, Change: FileKept})
+			continue
+		}
+		paths = append(paths, file.Path)
+	}
+	results, err := s.setupper.removeFiles(projectPath, paths, shared)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, kept...)
+
+	state.InitializedAgents = append(state.InitializedAgents[:index], state.InitializedAgents[index+1:]...)
+	if err := s.state.Save(projectPath, state); err != nil {
+		return nil, err
+	}
+	if err := s.setupper.pruneBases(projectPath, state); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Outdated 比较 state.json 中记录的模板 hash 与当前 Archie 生成的模板，
+// 返回需要 archie sync 的 agents（未注册的 agent 被忽略）
+func (s *Syncer) Outdated(projectPath string) ([]OutdatedAgent, error) {
+	state, err := s.state.Load(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	outdated := []OutdatedAgent{}
+	for _, agentState := range state.InitializedAgents {
+		hash, err := TemplateHash(projectPath, agentState.AgentName, SetupOptions{EnabledCommands: agentState.EnabledCommands})
+		if err != nil {
+			continue
+		}
+		if hash != agentState.TemplateHash {
+			outdated = append(outdated, OutdatedAgent{
+				Name:             agentState.AgentName,
+				InstalledVersion: agentState.ArchieVersion,
+			})
+		}
+	}
+
+	sort.Slice(outdated, func(i, j int) bool {
+		return outdated[i].Name < outdated[j].Name
+	})
+	return outdated, nil
+}
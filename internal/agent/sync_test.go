@@ -0,0 +1,459 @@
+package agent
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/GarrickZ2/archie/internal/agentdoc"
+	"github.com/GarrickZ2/archie/resources"
+)
+
+func TestSyncer_Sync(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	state := NewStateManager(fs)
+	if err := state.MarkInitialized("/project", AgentState{AgentName: "claude-code", EnabledCommands: []string{"design"}}); err != nil {
+		t.Fatalf("MarkInitialized() error = %v", err)
+	}
+	if err := afero.WriteFile(fs, "/project/.archie/docs/schema/removed.md", []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	syncer := NewSyncer(fs)
+	outdated, err := syncer.Outdated("/project")
+	if err != nil {
+		t.Fatalf("Outdated() error = %v", err)
+	}
+	if len(outdated) != 1 || outdated[0].Name != "claude-code" {
+		t.Errorf("Outdated() = %v, want claude-code (no template hash recorded)", outdated)
+	}
+
+	result, err := syncer.Sync(context.Background(), "/project")
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(result.Agents) != 1 {
+		t.Fatalf("got %d agents, want 1", len(result.Agents))
+	}
+
+	changes := make(map[string]FileChange)
+	for _, file := range result.Agents[0].Files {
+		changes[file.Path] = file.Change
+	}
+	if changes[".claude/commands/archie-design.md"] != FileAdded {
+		t.Errorf("archie-design.md change = %q, want added", changes[".claude/commands/archie-design.md"])
+	}
+	if _, ok := changes[".claude/commands/archie-spec.md"]; ok {
+		t.Error("archie-spec.md was not enabled at init and must not be installed")
+	}
+	if result.Counts()[FileRemoved] != 1 {
+		t.Errorf("removed = %d, want 1 (.archie/docs/schema/removed.md)", result.Counts()[FileRemoved])
+	}
+
+	// A second sync changes nothing and the prompts are up to date
+	result, err = syncer.Sync(context.Background(), "/project")
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	counts := result.Counts()
+	if counts[FileAdded]+counts[FileUpdated]+counts[FileRemoved] != 0 {
+		t.Errorf("second sync changed files: %v", counts)
+	}
+	if outdated, _ = syncer.Outdated("/project"); len(outdated) != 0 {
+		t.Errorf("Outdated() after sync = %v, want none", outdated)
+	}
+
+	// A file recorded in the manifest but no longer generated is an orphan
+	projectState, err := state.Load("/project")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(projectState.InitializedAgents[0].Files) == 0 {
+		t.Fatal("sync did not record a manifest")
+	}
+	projectState.InitializedAgents[0].Files = append(projectState.InitializedAgents[0].Files,
+		InstalledFile{Path: ".claude/commands/legacy/archie-old.md", Hash: hashContent("old")})
+	if err := state.Save("/project", projectState); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/project/.claude/commands/legacy/archie-old.md", []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = syncer.Sync(context.Background(), "/project")
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if result.Counts()[FileRemoved] != 1 {
+		t.Errorf("removed = %d, want 1 (orphaned archie-old.md)", result.Counts()[FileRemoved])
+	}
+	if exists, _ := afero.DirExists(fs, "/project/.claude/commands/legacy"); exists {
+		t.Error("empty directory of the orphan was not pruned")
+	}
+}
+
+func TestSyncer_Remove(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	state := NewStateManager(fs)
+	if err := state.MarkInitialized("/project",
+		AgentState{AgentName: "cursor", EnabledCommands: []string{"design"}},
+		AgentState{AgentName: "windsurf", EnabledCommands: []string{"design"}},
+	); err != nil {
+		t.Fatalf("MarkInitialized() error = %v", err)
+	}
+	if err := afero.WriteFile(fs, "/project/.cursor/rules.md", []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	syncer := NewSyncer(fs)
+	if _, err := syncer.Sync(context.Background(), "/project"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	removed, err := syncer.Remove("/project", "cursor")
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0].Path != ".cursor/commands/archie-design.md" {
+		t.Errorf("Remove() = %v, want only .cursor/commands/archie-design.md", removed)
+	}
+	if exists, _ := afero.DirExists(fs, "/project/.cursor/commands"); exists {
+		t.Error(".cursor/commands is empty and should be pruned")
+	}
+	if exists, _ := afero.Exists(fs, "/project/.cursor/rules.md"); !exists {
+		t.Error("files not installed by Archie must be kept")
+	}
+	if exists, _ := afero.Exists(fs, "/project/AGENTS.md"); !exists {
+		t.Error("AGENTS.md is still used by windsurf and must be kept")
+	}
+
+	if initialized, _ := state.IsInitialized("/project", "cursor"); initialized {
+		t.Error("cursor is still recorded in state.json")
+	}
+	if _, err := syncer.Remove("/project", "cursor"); err == nil {
+		t.Error("Remove() of an agent that is not initialized should fail")
+	}
+
+	if _, err := syncer.Remove("/project", "windsurf"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if exists, _ := afero.Exists(fs, "/project/AGENTS.md"); exists {
+		t.Error("AGENTS.md should be removed with the last agent using it")
+	}
+}
+
+func TestSyncer_FilenameTemplate(t *testing.T) {
+	Register(NewCustomAgent(CustomAgentConfig{
+		Name:             "test-filename-template",
+		AgentDoc:         "AGENTS.md",
+		CommandsDir:      "prompts",
+		FilenameTemplate: "archie/{{project}}-{{command}}",
+	}))
+
+	fs := afero.NewMemMapFs()
+	state := NewStateManager(fs)
+	for _, project := range []string{"/work/shop", "/work/blog"} {
+		if err := state.MarkInitialized(project, AgentState{AgentName: "test-filename-template", EnabledCommands: []string{"design"}}); err != nil {
+			t.Fatalf("MarkInitialized() error = %v", err)
+		}
+		if _, err := NewSyncer(fs).Sync(context.Background(), project); err != nil {
+			t.Fatalf("Sync(%s) error = %v", project, err)
+		}
+	}
+
+	projectState, err := state.Load("/work/shop")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	paths := manifestPaths(projectState.InitializedAgents[0].Files)
+	want := filepath.Join("prompts", "archie", projectName("/work/shop")+"-archie-design.md")
+	if !paths[want] {
+		t.Errorf("manifest = %v, want the resolved path %s", projectState.InitializedAgents[0].Files, want)
+	}
+	if exists, _ := afero.Exists(fs, filepath.Join("/work/shop", want)); !exists {
+		t.Errorf("%s was not written", want)
+	}
+
+	// Removing the agent from one project leaves the other project's files alone
+	if _, err := NewSyncer(fs).Remove("/work/shop", "test-filename-template"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if exists, _ := afero.Exists(fs, filepath.Join("/work/blog/prompts/archie", projectName("/work/blog")+"-archie-design.md")); !exists {
+		t.Error("files installed for another project must be kept")
+	}
+}
+
+func TestSyncer_FilenameTemplate_SharedGlobalDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	Register(NewCustomAgent(CustomAgentConfig{
+		Name:             "test-filename-global",
+		CommandsDir:      "~/.prompts",
+		FilenameTemplate: "{{project}}-{{command}}.{{ext}}",
+	}))
+
+	// Two checkouts with the same directory name share ~/.prompts
+	fs := afero.NewMemMapFs()
+	state := NewStateManager(fs)
+	projects := []string{"/a/shop", "/b/shop"}
+	for _, project := range projects {
+		if err := state.MarkInitialized(project, AgentState{AgentName: "test-filename-global", EnabledCommands: []string{"design"}}); err != nil {
+			t.Fatalf("MarkInitialized() error = %v", err)
+		}
+		if _, err := NewSyncer(fs).Sync(context.Background(), project); err != nil {
+			t.Fatalf("Sync(%s) error = %v", project, err)
+		}
+	}
+
+	paths := make([]string, len(projects))
+	for i, project := range projects {
+		paths[i] = filepath.Join(home, ".prompts", projectName(project)+"-archie-design.md")
+		if exists, _ := afero.Exists(fs, paths[i]); !exists {
+			t.Fatalf("%s was not written for %s", paths[i], project)
+		}
+	}
+	if paths[0] == paths[1] {
+		t.Fatalf("projects with the same directory name resolve to the same file %s", paths[0])
+	}
+
+	if _, err := NewSyncer(fs).Remove("/a/shop", "test-filename-global"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if exists, _ := afero.Exists(fs, paths[0]); exists {
+		t.Errorf("%s should be removed with the agent", paths[0])
+	}
+	if exists, _ := afero.Exists(fs, paths[1]); !exists {
+		t.Error("the other checkout's file in the global folder must be kept")
+	}
+}
+
+func TestSyncer_Sync_EditedFileSameTemplate(t *testing.T) {
+	const designPath = ".claude/commands/archie-design.md"
+	fs := afero.NewMemMapFs()
+	if err := NewStateManager(fs).MarkInitialized("/project", AgentState{AgentName: "claude-code", EnabledCommands: []string{"design"}}); err != nil {
+		t.Fatalf("MarkInitialized() error = %v", err)
+	}
+
+	syncer := NewSyncer(fs)
+	prompted := 0
+	syncer.SetModifiedHandler(func(ModifiedFile) (Resolution, error) {
+		prompted++
+		return ResolveNewCopy, nil
+	})
+	if _, err := syncer.Sync(context.Background(), "/project"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	template, err := afero.ReadFile(fs, "/project/"+designPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := string(template) + "my note\n"
+	if err := afero.WriteFile(fs, "/project/"+designPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing new has shipped, so the edit is kept without asking, every time
+	for i := 0; i < 2; i++ {
+		result, err := syncer.Sync(context.Background(), "/project")
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		for _, file := range result.Agents[0].Files {
+			if file.Path == designPath && file.Change != FileKept {
+				t.Errorf("sync %d: change = %q, want %q", i+1, file.Change, FileKept)
+			}
+		}
+	}
+	if prompted != 0 {
+		t.Errorf("handler called %d times, want 0", prompted)
+	}
+	if exists, _ := afero.Exists(fs, "/project/"+designPath+".new"); exists {
+		t.Error("no .new copy should be written when the template is unchanged")
+	}
+	if data, _ := afero.ReadFile(fs, "/project/"+designPath); string(data) != edited {
+		t.Error("edited file was overwritten")
+	}
+}
+
+func TestSyncer_Remove_EditedFiles(t *testing.T) {
+	const designPath = ".claude/commands/archie-design.md"
+	fs := afero.NewMemMapFs()
+	state := NewStateManager(fs)
+	install := func() {
+		t.Helper()
+		if err := state.MarkInitialized("/project", AgentState{AgentName: "claude-code", EnabledCommands: []string{"design", "spec"}}); err != nil {
+			t.Fatalf("MarkInitialized() error = %v", err)
+		}
+		if _, err := NewSyncer(fs).Sync(context.Background(), "/project"); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if err := afero.WriteFile(fs, "/project/"+designPath, []byte("my own design prompt\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := afero.WriteFile(fs, "/project/"+designPath+".new", []byte("template\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	install()
+	removed, err := NewSyncer(fs).Remove("/project", "claude-code")
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	changes := make(map[string]FileChange)
+	for _, file := range removed {
+		changes[file.Path] = file.Change
+	}
+	if changes[designPath] != FileKept {
+		t.Errorf("%s change = %q, want %q", designPath, changes[designPath], FileKept)
+	}
+	if data, _ := afero.ReadFile(fs, "/project/"+designPath); string(data) != "my own design prompt\n" {
+		t.Error("edited file must be kept")
+	}
+	if exists, _ := afero.Exists(fs, "/project/"+designPath+".new"); exists {
+		t.Error(".new copy should be deleted")
+	}
+	if exists, _ := afero.Exists(fs, "/project/.claude/commands/archie-spec.md"); exists {
+		t.Error("unedited files should be deleted")
+	}
+
+	// With force the edited file goes too and the directory is pruned
+	install()
+	syncer := NewSyncer(fs)
+	syncer.SetForce(true)
+	if _, err := syncer.Remove("/project", "claude-code"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if exists, _ := afero.DirExists(fs, "/project/.claude/commands"); exists {
+		t.Error(".claude/commands should be pruned after a forced remove")
+	}
+}
+
+// dropManifest clears the recorded files, like an install made before Archie kept a manifest
+func dropManifest(t *testing.T, fs afero.Fs, projectPath string) {
+	t.Helper()
+	state := NewStateManager(fs)
+	projectState, err := state.Load(projectPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for i := range projectState.InitializedAgents {
+		projectState.InitializedAgents[i].Files = nil
+	}
+	if err := state.Save(projectPath, projectState); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+}
+
+func TestSyncer_Remove_AllFilesEdited(t *testing.T) {
+	const designPath = ".cursor/commands/archie-design.md"
+	for _, legacy := range []bool{false, true} {
+		fs := afero.NewMemMapFs()
+		if err := NewStateManager(fs).MarkInitialized("/project", AgentState{AgentName: "cursor", EnabledCommands: []string{"design"}}); err != nil {
+			t.Fatalf("MarkInitialized() error = %v", err)
+		}
+		if _, err := NewSyncer(fs).Sync(context.Background(), "/project"); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if legacy {
+			dropManifest(t, fs, "/project")
+		}
+
+		// Edit every file the agent owns, including the managed block of AGENTS.md
+		if err := afero.WriteFile(fs, "/project/"+designPath, []byte("my own design prompt\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		doc, _ := afero.ReadFile(fs, "/project/AGENTS.md")
+		edited := strings.Replace(string(doc), agentdoc.Begin+"\n", agentdoc.Begin+"\nmy own note\n", 1)
+		if err := afero.WriteFile(fs, "/project/AGENTS.md", []byte(edited), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := NewSyncer(fs).Remove("/project", "cursor")
+		if err != nil {
+			t.Fatalf("Remove(legacy=%v) error = %v", legacy, err)
+		}
+		for _, file := range results {
+			if file.Change != FileKept {
+				t.Errorf("legacy=%v: %s change = %q, want only kept files", legacy, file.Path, file.Change)
+			}
+		}
+		if data, _ := afero.ReadFile(fs, "/project/"+designPath); string(data) != "my own design prompt\n" {
+			t.Errorf("legacy=%v: edited %s must be kept", legacy, designPath)
+		}
+		if data, _ := afero.ReadFile(fs, "/project/AGENTS.md"); string(data) != edited {
+			t.Errorf("legacy=%v: edited AGENTS.md must be kept", legacy)
+		}
+	}
+}
+
+func TestSyncer_Sync_LegacyInstallEdited(t *testing.T) {
+	const designPath = ".claude/commands/archie-design.md"
+	fs := afero.NewMemMapFs()
+	if err := NewStateManager(fs).MarkInitialized("/project", AgentState{AgentName: "claude-code", EnabledCommands: []string{"design"}}); err != nil {
+		t.Fatalf("MarkInitialized() error = %v", err)
+	}
+	if _, err := NewSyncer(fs).Sync(context.Background(), "/project"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	dropManifest(t, fs, "/project")
+
+	template, _ := afero.ReadFile(fs, "/project/"+designPath)
+	edited := string(template) + "\nAlways cite the RFC.\n"
+	if err := afero.WriteFile(fs, "/project/"+designPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	syncer := NewSyncer(fs)
+	var prompted []ModifiedFile
+	syncer.SetModifiedHandler(func(file ModifiedFile) (Resolution, error) {
+		prompted = append(prompted, file)
+		return ResolveNewCopy, nil
+	})
+	if _, err := syncer.Sync(context.Background(), "/project"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(prompted) != 1 || prompted[0].Path != designPath || prompted[0].CanMerge {
+		t.Fatalf("OnModified calls = %+v, want one for %s without a merge base", prompted, designPath)
+	}
+	if data, _ := afero.ReadFile(fs, "/project/"+designPath); string(data) != edited {
+		t.Error("the edit of a legacy install must not be overwritten")
+	}
+	if data, _ := afero.ReadFile(fs, "/project/"+designPath+".new"); string(data) != string(template) {
+		t.Error("the template should be written next to the edited file")
+	}
+
+	// The edit is now recorded against the current template: the next sync keeps it without asking
+	prompted = nil
+	if _, err := syncer.Sync(context.Background(), "/project"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(prompted) != 0 {
+		t.Errorf("OnModified calls = %+v, want none for an unchanged template", prompted)
+	}
+}
+
+func TestSyncer_Sync_UnmarkedAgentDoc(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	// CLAUDE.md written by an Archie version without markers, with a project note below it
+	legacy := resources.AgentsMdContent + "\n## Team notes\nUse tabs.\n"
+	if err := afero.WriteFile(fs, "/project/CLAUDE.md", []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewStateManager(fs).MarkInitialized("/project", AgentState{AgentName: "claude-code", EnabledCommands: []string{"design"}}); err != nil {
+		t.Fatalf("MarkInitialized() error = %v", err)
+	}
+	if _, err := NewSyncer(fs).Sync(context.Background(), "/project"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	data, _ := afero.ReadFile(fs, "/project/CLAUDE.md")
+	want := agentdoc.Block(resources.AgentsMdContent) + "\n## Team notes\nUse tabs.\n"
+	if string(data) != want {
+		t.Errorf("CLAUDE.md = %q, want the Archie instructions once, in the managed block", data)
+	}
+}
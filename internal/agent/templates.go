@@ -3,6 +3,8 @@ package agent
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/GarrickZ2/archie/resources"
 )
@@ -18,26 +20,90 @@ func GetCommands() map[string]*resources.CommandTemplate {
 	return commands
 }
 
+// commandPrefix is the prefix shared by all command template names
+const commandPrefix = "archie-"
+
+// CommandNames returns the names of all command templates, sorted (e.g. "archie-design")
+func CommandNames() []string {
+	names := make([]string, 0)
+	for name := range GetCommands() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveCommandNames maps user-given command names to template names.
+// Both "design" and "archie-design" are accepted; unknown names are an error.
+func ResolveCommandNames(names []string) ([]string, error) {
+	known := make(map[string]bool)
+	for _, name := range CommandNames() {
+		known[name] = true
+	}
+
+	resolved := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !strings.HasPrefix(name, commandPrefix) {
+			name = commandPrefix + name
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("unknown command %q (available: %s)", strings.TrimPrefix(name, commandPrefix), strings.Join(shortCommandNames(), ", "))
+		}
+		resolved = append(resolved, name)
+	}
+	return resolved, nil
+}
+
+// shortCommandNames returns command names without the archie- prefix
+func shortCommandNames() []string {
+	names := CommandNames()
+	for i, name := range names {
+		names[i] = strings.TrimPrefix(name, commandPrefix)
+	}
+	return names
+}
+
 // GetFormattedCommands returns formatted commands for a specific agent
 func GetFormattedCommands(agentName string) (map[string]string, error) {
+	return formatTemplates(agentName, GetCommands(), false)
+}
+
+// GetFormattedSubAgents returns the sub-agents formatted for a specific agent,
+// using its sub_agent_mapping
+func GetFormattedSubAgents(agentName string) (map[string]string, error) {
+	return formatTemplates(agentName, GetSubAgentTemplates(), true)
+}
+
+// formatTemplates formats templates with the agent's formatter and file extension.
+// Sub-agents use the agent's sub-agent mapping instead of the command mapping.
+func formatTemplates(agentName string, templates map[string]*resources.CommandTemplate, subAgents bool) (map[string]string, error) {
 	agent, err := Get(agentName)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get agent config
-	var fileFormat string
+	var fileFormat, ext string
 	var mapping map[string]string
 
 	if customAgent, ok := agent.(*CustomAgent); ok {
 		fileFormat = customAgent.config.FileFormat
 		mapping = customAgent.config.Mapping
+		if subAgents {
+			mapping = customAgent.config.SubAgentMapping
+		}
+		ext = customAgent.FileExtension()
 	} else {
 		// For builtin agents, get from agents.json
 		// This should have been loaded via LoadBuiltinAgents
 		// Fallback to default
 		fileFormat = "md"
 		mapping = map[string]string{"content": "[CONTENT]"}
+		ext = "md"
 	}
 
 	// Get formatter
@@ -46,22 +112,19 @@ func GetFormattedCommands(agentName string) (map[string]string, error) {
 		return nil, err
 	}
 
-	// Load raw templates
-	rawTemplates := GetCommands()
+	kind := "command"
+	if subAgents {
+		kind = "sub-agent"
+	}
 	formatted := make(map[string]string)
 
 	// Format each template
-	for filename, template := range rawTemplates {
-		// Determine file extension based on format
-		ext := ".md"
-		if fileFormat == "toml" {
-			ext = ".toml"
-		}
-		formattedFilename := filename + ext
+	for filename, template := range templates {
+		formattedFilename := filename + "." + ext
 
 		formattedContent, err := formatter.Format(template, mapping)
 		if err != nil {
-			return nil, fmt.Errorf("failed to format command %s: %w", filename, err)
+			return nil, fmt.Errorf("failed to format %s %s: %w", kind, filename, err)
 		}
 
 		formatted[formattedFilename] = formattedContent
@@ -70,6 +133,17 @@ func GetFormattedCommands(agentName string) (map[string]string, error) {
 	return formatted, nil
 }
 
+// GetSubAgentTemplates returns all subagent templates as structured templates
+// This function loads subagents from the embedded FS
+func GetSubAgentTemplates() map[string]*resources.CommandTemplate {
+	subAgents, err := resources.LoadSubAgentTemplates()
+	if err != nil {
+		log.Printf("Warning: failed to load subagents: %v", err)
+		return make(map[string]*resources.CommandTemplate)
+	}
+	return subAgents
+}
+
 // GetSubAgents returns all subagent templates
 // This function loads subagents from the embedded FS
 func GetSubAgents() map[string]string {
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -41,3 +42,42 @@ func TestGetSubAgents(t *testing.T) {
 		}
 	}
 }
+
+func TestGetFormattedSubAgents(t *testing.T) {
+	subAgents, err := GetFormattedSubAgents("claude-code")
+	if err != nil {
+		t.Fatalf("GetFormattedSubAgents(claude-code) error = %v", err)
+	}
+	// The default md mapping installs the sub-agent files exactly as shipped
+	raw := GetSubAgents()
+	if len(subAgents) != len(raw) {
+		t.Errorf("GetFormattedSubAgents(claude-code) returned %d sub-agents, want %d", len(subAgents), len(raw))
+	}
+	for filename, want := range raw {
+		if got := subAgents[filename]; got != want {
+			t.Errorf("%s differs from the template:\ngot:\n%s\nwant:\n%s", filename, got, want)
+		}
+	}
+
+	Register(NewCustomAgent(CustomAgentConfig{
+		Name:            "test-toml-subagents",
+		CommandsDir:     ".prompts",
+		SubAgentsDir:    ".agents",
+		FileFormat:      "toml",
+		SubAgentMapping: map[string]string{"name": "title", "content": "instructions"},
+	}))
+	subAgents, err = GetFormattedSubAgents("test-toml-subagents")
+	if err != nil {
+		t.Fatalf("GetFormattedSubAgents() error = %v", err)
+	}
+	api, ok := subAgents["archie-api.toml"]
+	if !ok {
+		t.Fatalf("archie-api.toml not found, got %d sub-agents", len(subAgents))
+	}
+	if !strings.Contains(api, `title = "Archie API Designer"`) {
+		t.Errorf("name should be mapped to title, got:\n%s", api)
+	}
+	if !strings.Contains(api, `instructions = """`) || strings.Contains(api, "---") {
+		t.Errorf("the body should be mapped to instructions without frontmatter, got:\n%s", api)
+	}
+}
@@ -2,8 +2,10 @@ package agent
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/afero"
 
 	"github.com/GarrickZ2/archie/internal/ui"
 )
@@ -14,7 +16,8 @@ const (
 
 // TUISelector TUI 选择器
 type TUISelector struct {
-	projectPath string
+	projectPath  string
+	defaultAgent string // 默认选中的 agent（配置中的 agent.default）
 }
 
 // NewTUISelector 创建 TUI 选择器
@@ -22,10 +25,16 @@ func NewTUISelector(projectPath string) *TUISelector {
 	return &TUISelector{projectPath: projectPath}
 }
 
-// SelectAgent 选择 agent（带 TUI 界面）
-func (s *TUISelector) SelectAgent() (selectedName string, isCustom bool, err error) {
-	// 加载已有的自定义 agents（从用户根目录）
-	if err := LoadAndRegister(); err != nil {
+// SetDefaultAgent 设置默认选中的 agent
+func (s *TUISelector) SetDefaultAgent(name string) {
+	s.defaultAgent = name
+}
+
+// SelectAgents 选择一个或多个 agent（带 TUI 多选界面）
+// 返回选中的 agent 名称，按选项顺序排列
+func (s *TUISelector) SelectAgents() ([]string, error) {
+	// 加载已有的自定义 agents（用户存储和项目存储）
+	if err := LoadAndRegister(s.projectPath); err != nil {
 		// 如果加载失败（比如文件不存在），继续执行
 		// 但不是错误，因为可能还没有自定义 agents
 	}
@@ -33,12 +42,13 @@ func (s *TUISelector) SelectAgent() (selectedName string, isCustom bool, err err
 	// 获取所有可用的 agents
 	agents, err := GetAllAgents(s.projectPath)
 	if err != nil {
-		return "", false, fmt.Errorf("failed to get agents: %w", err)
+		return nil, fmt.Errorf("failed to get agents: %w", err)
 	}
 
 	// 构建选项列表
 	options := make([]string, 0, len(agents)+1)
 	optionToAgent := make(map[string]AgentInfo)
+	defaults := []string{}
 
 	for _, agent := range agents {
 		// 添加勾选标记
@@ -50,6 +60,9 @@ func (s *TUISelector) SelectAgent() (selectedName string, isCustom bool, err err
 		option := prefix + agent.DisplayName
 		options = append(options, option)
 		optionToAgent[option] = agent
+		if agent.Name == s.defaultAgent {
+			defaults = append(defaults, option)
+		}
 	}
 
 	// 添加 "Add new custom agent" 选项
@@ -58,41 +71,56 @@ func (s *TUISelector) SelectAgent() (selectedName string, isCustom bool, err err
 	// Note: Welcome banner is now shown by the init command
 	// Just show the agent selection prompt
 
-	// 使用 survey 进行选择
-	var selected string
-	prompt := &survey.Select{
-		Message: "Select Agent:",
+	// 使用 survey 进行多选
+	var selected []string
+	prompt := &survey.MultiSelect{
+		Message: "Select Agents:",
+		Help:    "Space to select, Enter to confirm. ✓ marks agents already initialized.",
 		Options: options,
-		Default: options[0],
+		Default: defaults,
 	}
 
-	if err := survey.AskOne(prompt, &selected); err != nil {
-		return "", false, fmt.Errorf("selection cancelled")
+	if err := survey.AskOne(prompt, &selected, survey.WithValidator(survey.MinItems(1))); err != nil {
+		return nil, fmt.Errorf("selection cancelled")
 	}
 
-	// 处理 "Add new custom agent" 选项
-	if selected == addNewCustomAgentOption {
-		config, err := s.promptCustomAgentConfig()
-		if err != nil {
-			return "", false, err
-		}
+	names := make([]string, 0, len(selected))
+	for _, option := range selected {
+		// 处理 "Add new custom agent" 选项
+		if option == addNewCustomAgentOption {
+			config, err := s.promptCustomAgentConfig()
+			if err != nil {
+				return nil, err
+			}
 
-		// 保存自定义 agent 配置到用户根目录
-		store := NewCustomAgentStore(nil)
-		if err := store.Add(config); err != nil {
-			return "", false, fmt.Errorf("failed to save custom agent: %w", err)
-		}
+			// 保存到用户选择的存储（项目或用户根目录）
+			store, err := selectAgentStore(s.projectPath)
+			if err != nil {
+				return nil, err
+			}
+			if err := store.Add(config); err != nil {
+				return nil, fmt.Errorf("failed to save custom agent: %w", err)
+			}
+
+			// 注册新的自定义 agent
+			store.RegisterAgent(config)
 
-		// 注册新的自定义 agent
-		customAgent := NewCustomAgent(config)
-		Register(customAgent)
+			names = append(names, config.Name)
+			continue
+		}
 
-		return config.Name, true, nil
+		names = append(names, optionToAgent[option].Name)
 	}
 
-	// 返回选中的 agent
-	agentInfo := optionToAgent[selected]
-	return agentInfo.Name, agentInfo.IsCustom, nil
+	return names, nil
+}
+
+// validateAgentName 拒绝官方 agent 的名称（survey 校验器）
+func validateAgentName(answer interface{}) error {
+	if name, ok := answer.(string); ok && IsOfficialName(name) {
+		return fmt.Errorf("'%s' is the name of an official agent; choose another name", name)
+	}
+	return nil
 }
 
 // promptCustomAgentConfig 提示用户输入自定义 agent 配置
@@ -102,9 +130,9 @@ func (s *TUISelector) promptCustomAgentConfig() (CustomAgentConfig, error) {
 	// 1. Agent Name
 	namePrompt := &survey.Input{
 		Message: "Agent name:",
-		Help:    "The name of your custom agent (e.g., 'cursor', 'my-agent')",
+		Help:    "The name of your custom agent (e.g., 'my-agent'); names of official agents are reserved",
 	}
-	if err := survey.AskOne(namePrompt, &config.Name, survey.WithValidator(survey.Required)); err != nil {
+	if err := survey.AskOne(namePrompt, &config.Name, survey.WithValidator(survey.ComposeValidators(survey.Required, validateAgentName))); err != nil {
 		return config, err
 	}
 
@@ -143,8 +171,8 @@ func (s *TUISelector) promptCustomAgentConfig() (CustomAgentConfig, error) {
 	// 5. File Format
 	fileFormatPrompt := &survey.Select{
 		Message: "File format:",
-		Help:    "Output format for command files (md = Markdown with frontmatter, toml = TOML)",
-		Options: []string{"md", "toml"},
+		Help:    "Output format for command files (md/mdc = Markdown with frontmatter, toml, json, yaml)",
+		Options: FormatNames(),
 		Default: "md",
 	}
 	if err := survey.AskOne(fileFormatPrompt, &config.FileFormat); err != nil {
@@ -162,6 +190,36 @@ func (s *TUISelector) promptCustomAgentConfig() (CustomAgentConfig, error) {
 	return config, nil
 }
 
+// selectAgentStore 询问新的自定义 agent 保存到项目还是用户存储
+// 不在 Archie 项目中时直接使用用户存储
+func selectAgentStore(projectPath string) (*CustomAgentStore, error) {
+	if projectPath == "" {
+		return NewCustomAgentStore(nil), nil
+	}
+	if isProject, _ := afero.DirExists(afero.NewOsFs(), filepath.Join(projectPath, ".archie")); !isProject {
+		return NewCustomAgentStore(nil), nil
+	}
+
+	const (
+		projectOption = "Project (.archie/agents.json, shared with the team through the repo)"
+		userOption    = "User (~/.archie/custom_agents.json, only on this machine)"
+	)
+	var selected string
+	prompt := &survey.Select{
+		Message: "Save the agent to:",
+		Options: []string{projectOption, userOption},
+		Default: projectOption,
+	}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return nil, err
+	}
+
+	if selected == projectOption {
+		return NewProjectAgentStore(nil, projectPath), nil
+	}
+	return NewCustomAgentStore(nil), nil
+}
+
 // ConfirmReconfigure 确认是否重新配置
 func (s *TUISelector) ConfirmReconfigure(agentName string) (bool, error) {
 	var confirm bool
@@ -177,38 +235,53 @@ func (s *TUISelector) ConfirmReconfigure(agentName string) (bool, error) {
 	return confirm, nil
 }
 
-// CustomAgentManager 管理 custom agents
+// CustomAgentManager 管理 custom agents（项目存储和用户存储）
 type CustomAgentManager struct {
-	store *CustomAgentStore
+	projectPath string
+	stores      []*CustomAgentStore
 }
 
-// NewCustomAgentManager 创建管理器
-func NewCustomAgentManager() *CustomAgentManager {
-	return &CustomAgentManager{
-		store: NewCustomAgentStore(nil),
+// NewCustomAgentManager 创建管理器，projectPath 为空时只管理用户存储
+func NewCustomAgentManager(projectPath string) *CustomAgentManager {
+	stores := []*CustomAgentStore{NewCustomAgentStore(nil)}
+	if projectPath != "" {
+		stores = append(stores, NewProjectAgentStore(nil, projectPath))
 	}
+	return &CustomAgentManager{projectPath: projectPath, stores: stores}
+}
+
+// storedAgent 某个存储中的一个 custom agent
+type storedAgent struct {
+	store *CustomAgentStore
+	name  string
 }
 
 // ShowManagementUI 显示管理界面
 func (m *CustomAgentManager) ShowManagementUI() error {
 	for {
-		// 加载所有 custom agents
-		configs, err := m.store.Load()
-		if err != nil {
-			return fmt.Errorf("failed to load custom agents: %w", err)
-		}
-
 		// 构建选项列表
 		options := []string{
 			"[+ Add new custom agent]",
 		}
 
-		// 添加现有的 custom agents（仅非官方的）
-		customConfigs := make([]CustomAgentConfig, 0)
-		for _, config := range configs {
-			if !config.Official {
-				customConfigs = append(customConfigs, config)
-				options = append(options, fmt.Sprintf("[-] Remove: %s", config.Name))
+		// 添加每个存储中的 custom agents（仅非官方的）
+		editOptions := make(map[string]storedAgent)
+		removeOptions := make(map[string]storedAgent)
+		for _, store := range m.stores {
+			configs, err := store.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load custom agents: %w", err)
+			}
+			for _, config := range configs {
+				if config.Official {
+					continue
+				}
+				target := storedAgent{store: store, name: config.Name}
+				editOption := fmt.Sprintf("[~] Edit: %s (%s)", config.Name, store.Scope())
+				removeOption := fmt.Sprintf("[-] Remove: %s (%s)", config.Name, store.Scope())
+				options = append(options, editOption, removeOption)
+				editOptions[editOption] = target
+				removeOptions[removeOption] = target
 			}
 		}
 
@@ -240,8 +313,19 @@ func (m *CustomAgentManager) ShowManagementUI() error {
 			return nil
 
 		default:
+			if target, ok := editOptions[selected]; ok {
+				if err := m.editCustomAgent(target); err != nil {
+					ui.ShowError(fmt.Sprintf("Failed to edit custom agent: %v", err))
+					fmt.Println()
+					continue
+				}
+				ui.ShowSuccess("Custom agent updated successfully!")
+				fmt.Println()
+				continue
+			}
+
 			// 删除 custom agent
-			if err := m.removeCustomAgent(selected, customConfigs); err != nil {
+			if err := m.removeCustomAgent(removeOptions[selected]); err != nil {
 				ui.ShowError(fmt.Sprintf("Failed to remove custom agent: %v", err))
 				fmt.Println()
 				continue
@@ -259,9 +343,9 @@ func (m *CustomAgentManager) addCustomAgent() error {
 	// 1. Agent Name
 	namePrompt := &survey.Input{
 		Message: "Agent name:",
-		Help:    "The name of your custom agent (e.g., 'cursor', 'my-agent')",
+		Help:    "The name of your custom agent (e.g., 'my-agent'); names of official agents are reserved",
 	}
-	if err := survey.AskOne(namePrompt, &config.Name, survey.WithValidator(survey.Required)); err != nil {
+	if err := survey.AskOne(namePrompt, &config.Name, survey.WithValidator(survey.ComposeValidators(survey.Required, validateAgentName))); err != nil {
 		return err
 	}
 
@@ -299,8 +383,8 @@ func (m *CustomAgentManager) addCustomAgent() error {
 	// 5. File Format
 	fileFormatPrompt := &survey.Select{
 		Message: "File format:",
-		Help:    "Output format for command files (md = Markdown with frontmatter, toml = TOML)",
-		Options: []string{"md", "toml"},
+		Help:    "Output format for command files (md/mdc = Markdown with frontmatter, toml, json, yaml)",
+		Options: FormatNames(),
 		Default: "md",
 	}
 	if err := survey.AskOne(fileFormatPrompt, &config.FileFormat); err != nil {
@@ -315,30 +399,159 @@ func (m *CustomAgentManager) addCustomAgent() error {
 		return err
 	}
 
-	// 保存
-	return m.store.Add(config)
+	// 保存到用户选择的存储
+	store, err := selectAgentStore(m.projectPath)
+	if err != nil {
+		return err
+	}
+	return store.Add(config)
 }
 
-// removeCustomAgent 删除 custom agent
-func (m *CustomAgentManager) removeCustomAgent(selected string, configs []CustomAgentConfig) error {
-	// 从选项中提取 agent 名称
-	// 格式: "[-] Remove: agent-name"
-	var agentName string
-	for _, config := range configs {
-		if selected == fmt.Sprintf("[-] Remove: %s", config.Name) {
-			agentName = config.Name
+// editCustomAgent 修改 custom agent 的配置（名称不变），验证通过后保存回原来的存储
+func (m *CustomAgentManager) editCustomAgent(target storedAgent) error {
+	configs, err := target.store.Load()
+	if err != nil {
+		return err
+	}
+	var config CustomAgentConfig
+	found := false
+	for _, c := range configs {
+		if c.Name == target.name {
+			config, found = c, true
 			break
 		}
 	}
+	if !found {
+		return fmt.Errorf("custom agent '%s' not found", target.name)
+	}
+
+	// 1. Background Doc Name
+	backgroundDocPrompt := &survey.Input{
+		Message: "Agent doc name:",
+		Help:    "The documentation file that provides context to the agent",
+		Default: config.AgentDoc,
+	}
+	if err := survey.AskOne(backgroundDocPrompt, &config.AgentDoc); err != nil {
+		return err
+	}
+	// 清空时使用默认值
+	if config.AgentDoc == "" {
+		config.AgentDoc = "AGENTS.md"
+	}
+
+	// 2. Commands Directory
+	commandsDirPrompt := &survey.Input{
+		Message: "Commands folder:",
+		Help:    "Directory where command prompts will be stored (e.g., '.cursor/rules/archie')",
+		Default: config.CommandsDir,
+	}
+	if err := survey.AskOne(commandsDirPrompt, &config.CommandsDir, survey.WithValidator(survey.Required)); err != nil {
+		return err
+	}
+
+	// 3. Sub-agents Directory (optional)
+	subAgentsDirPrompt := &survey.Input{
+		Message: "Sub-agents folder (optional):",
+		Help:    "Directory for sub-agent prompts. Leave empty if not supported.",
+		Default: config.SubAgentsDir,
+	}
+	if err := survey.AskOne(subAgentsDirPrompt, &config.SubAgentsDir); err != nil {
+		return err
+	}
+
+	// 4. File Format
+	previousFormat := config.FileFormat
+	if previousFormat == "" {
+		previousFormat = "md"
+	}
+	fileFormatPrompt := &survey.Select{
+		Message: "File format:",
+		Help:    "Output format for command files (md/mdc = Markdown with frontmatter, toml, json, yaml)",
+		Options: FormatNames(),
+		Default: previousFormat,
+	}
+	if err := survey.AskOne(fileFormatPrompt, &config.FileFormat); err != nil {
+		return err
+	}
+	extensionPrompt := &survey.Input{
+		Message: "File extension (optional, press Enter for the format default):",
+		Help:    "Extension of the command files without the dot (e.g., 'mdc')",
+		Default: config.FileExtension,
+	}
+	if err := survey.AskOne(extensionPrompt, &config.FileExtension); err != nil {
+		return err
+	}
+	filenamePrompt := &survey.Input{
+		Message: "Filename template (optional, press Enter for {{command}}.{{ext}}):",
+		Help:    "Command file path inside the commands folder; {{project}}, {{command}} and {{ext}} are replaced (e.g., '{{project}}-{{command}}.{{ext}}' for a global folder)",
+		Default: config.FilenameTemplate,
+	}
+	if err := survey.AskOne(filenamePrompt, &config.FilenameTemplate); err != nil {
+		return err
+	}
+
+	// 5. Mapping（格式改变时默认使用新格式的 mapping）
+	mapping := config.Mapping
+	if mapping == nil || config.FileFormat != previousFormat {
+		mapping = getDefaultMapping(config.FileFormat)
+	}
+	var mappingText string
+	mappingPrompt := &survey.Input{
+		Message: "Mapping (field=target, comma separated):",
+		Help:    "Maps command fields to the output format; the content needs [CONTENT] (md, mdc) or a key such as prompt",
+		Default: FormatMapping(mapping),
+	}
+	if err := survey.AskOne(mappingPrompt, &mappingText); err != nil {
+		return err
+	}
+	if config.Mapping, err = ParseMapping(mappingText); err != nil {
+		return err
+	}
+
+	// 6. Sub-agent mapping（只在支持 sub-agents 时询问）
+	if config.SubAgentsDir != "" {
+		subAgentMapping := config.SubAgentMapping
+		if subAgentMapping == nil || config.FileFormat != previousFormat {
+			subAgentMapping = getDefaultMapping(config.FileFormat)
+		}
+		var subAgentMappingText string
+		subAgentMappingPrompt := &survey.Input{
+			Message: "Sub-agent mapping (field=target, comma separated):",
+			Help:    "Maps sub-agent frontmatter fields (name, description, ...) and content to the output format",
+			Default: FormatMapping(subAgentMapping),
+		}
+		if err := survey.AskOne(subAgentMappingPrompt, &subAgentMappingText); err != nil {
+			return err
+		}
+		if config.SubAgentMapping, err = ParseMapping(subAgentMappingText); err != nil {
+			return err
+		}
+	} else {
+		config.SubAgentMapping = nil
+	}
+
+	// 验证配置
+	if err := ValidateCustomAgentConfig(config); err != nil {
+		return err
+	}
 
-	if agentName == "" {
+	if err := target.store.Put(config); err != nil {
+		return err
+	}
+	target.store.RegisterAgent(config)
+	return nil
+}
+
+// removeCustomAgent 删除 custom agent
+func (m *CustomAgentManager) removeCustomAgent(target storedAgent) error {
+	if target.store == nil {
 		return fmt.Errorf("invalid selection")
 	}
 
 	// 确认删除
 	var confirm bool
 	confirmPrompt := &survey.Confirm{
-		Message: fmt.Sprintf("Are you sure you want to remove custom agent '%s'?", agentName),
+		Message: fmt.Sprintf("Are you sure you want to remove custom agent '%s' from the %s store?", target.name, target.store.Scope()),
 		Default: false,
 	}
 
@@ -351,5 +564,5 @@ func (m *CustomAgentManager) removeCustomAgent(selected string, configs []Custom
 	}
 
 	// 删除
-	return m.store.Remove(agentName)
+	return target.store.Remove(target.name)
 }
@@ -0,0 +1,105 @@
+// Package agentdoc manages the block Archie owns inside agent background docs
+// (CLAUDE.md, AGENTS.md, GEMINI.md, ...). Everything outside the block belongs
+// to the project and is never touched.
+package agentdoc
+
+import "strings"
+
+// Markers delimiting the block Archie owns. They differ from the
+// <!-- ARCHIE:END --> that closes APPEND_ONLY regions in the workspace docs,
+// so quoting those docs inside or around the block cannot cut it short.
+const (
+	Begin = "<!-- ARCHIE:AGENT-DOC:BEGIN -->"
+	End   = "<!-- ARCHIE:AGENT-DOC:END -->"
+)
+
+// Block wraps content in the Archie markers
+func Block(content string) string {
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return Begin + "\n" + content + End + "\n"
+}
+
+// Extract returns the Archie block of a doc, markers included, and whether it has one
+func Extract(doc string) (string, bool) {
+	start, end, ok := find(doc)
+	if !ok {
+		return "", false
+	}
+	return doc[start:end], true
+}
+
+// Merge inserts block into doc, replacing the existing Archie block if there is
+// one and appending it otherwise. A doc written before the markers existed holds
+// the block's content unmarked; that copy is replaced instead of duplicated.
+// The rest of the doc is kept as is.
+func Merge(doc, block string) string {
+	if start, end, ok := find(doc); ok {
+		return doc[:start] + block + doc[end:]
+	}
+	if start, end, ok := findUnmarked(doc, block); ok {
+		return doc[:start] + block + doc[end:]
+	}
+	if strings.TrimSpace(doc) == "" {
+		return block
+	}
+	return strings.TrimRight(doc, "\n") + "\n\n" + block
+}
+
+// Remove returns doc without its Archie block. The result is empty when the
+// doc held nothing else.
+func Remove(doc string) string {
+	start, end, ok := find(doc)
+	if !ok {
+		return doc
+	}
+	before := strings.TrimRight(doc[:start], "\n")
+	after := strings.TrimLeft(doc[end:], "\n")
+	switch {
+	case strings.TrimSpace(before) == "" && strings.TrimSpace(after) == "":
+		return ""
+	case before == "":
+		return after
+	case after == "":
+		return before + "\n"
+	default:
+		return before + "\n\n" + after
+	}
+}
+
+// find locates the block from the begin marker to the end of the end marker's line
+func find(doc string) (start, end int, ok bool) {
+	start = strings.Index(doc, Begin)
+	if start < 0 {
+		return 0, 0, false
+	}
+	offset := strings.Index(doc[start:], End)
+	if offset < 0 {
+		return 0, 0, false
+	}
+	end = start + offset + len(End)
+	if strings.HasPrefix(doc[end:], "\n") {
+		end++
+	}
+	return start, end, true
+}
+
+// findUnmarked locates the content of block (without its markers) in a doc that
+// has no markers, up to the end of its last line
+func findUnmarked(doc, block string) (start, end int, ok bool) {
+	content := strings.TrimPrefix(block, Begin+"\n")
+	content = strings.TrimSpace(strings.TrimSuffix(content, End+"\n"))
+	if content == "" {
+		return 0, 0, false
+	}
+	start = strings.Index(doc, content)
+	if start < 0 {
+		return 0, 0, false
+	}
+	end = start + len(content)
+	if strings.HasPrefix(doc[end:], "\n") {
+		end++
+	}
+	return start, end, true
+}
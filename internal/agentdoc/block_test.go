@@ -0,0 +1,69 @@
+package agentdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	block := Block("# Archie\nRules")
+	assert.Equal(t, "<!-- ARCHIE:AGENT-DOC:BEGIN -->\n# Archie\nRules\n<!-- ARCHIE:AGENT-DOC:END -->\n", block)
+
+	// New or empty doc
+	assert.Equal(t, block, Merge("", block))
+
+	// Existing instructions are kept and the block is appended
+	doc := Merge("# Team rules\nUse tabs.\n", block)
+	assert.Equal(t, "# Team rules\nUse tabs.\n\n"+block, doc)
+
+	// Re-running replaces only the block
+	newBlock := Block("# Archie v2")
+	doc = Merge(doc+"\n## Notes\n", newBlock)
+	assert.Equal(t, "# Team rules\nUse tabs.\n\n"+newBlock+"\n## Notes\n", doc)
+
+	extracted, ok := Extract(doc)
+	assert.True(t, ok)
+	assert.Equal(t, newBlock, extracted)
+
+	_, ok = Extract("# Team rules\n")
+	assert.False(t, ok)
+}
+
+func TestMerge_UnmarkedLegacyContent(t *testing.T) {
+	block := Block("# Archie\nRules\n")
+
+	// A doc written before the markers existed holds the content without them
+	assert.Equal(t, block, Merge("# Archie\nRules\n", block))
+
+	// Project instructions added around the legacy content are kept
+	doc := Merge("# Team rules\n\n# Archie\nRules\n\n## Notes\n", block)
+	assert.Equal(t, "# Team rules\n\n"+block+"\n## Notes\n", doc)
+
+	// Different (e.g. older) content is not recognized and the block is appended
+	assert.Equal(t, "# Archie\nOld rules\n\n"+block, Merge("# Archie\nOld rules\n", block))
+}
+
+func TestRemove(t *testing.T) {
+	block := Block("# Archie")
+	assert.Equal(t, "", Remove(block))
+	assert.Equal(t, "# Team rules\n", Remove("# Team rules\n\n"+block))
+	assert.Equal(t, "# Team rules\n\n## Notes\n", Remove("# Team rules\n\n"+block+"\n## Notes\n"))
+	assert.Equal(t, "# Team rules\n", Remove("# Team rules\n"))
+}
+
+func TestMerge_AppendOnlyMarkers(t *testing.T) {
+	// The workspace docs close APPEND_ONLY regions with <!-- ARCHIE:END -->
+	quoted := "Logs are kept between\n<!-- ARCHIE:APPEND_ONLY -->\n...\n<!-- ARCHIE:END -->\n"
+	block := Block("# Archie\n" + quoted + "Never edit them.")
+
+	doc := Merge("# Team rules\n"+quoted, block)
+	extracted, ok := Extract(doc)
+	assert.True(t, ok)
+	assert.Equal(t, block, extracted)
+
+	newBlock := Block("# Archie v2")
+	doc = Merge(doc+"\n## Notes\n", newBlock)
+	assert.Equal(t, "# Team rules\n"+quoted+"\n"+newBlock+"\n## Notes\n", doc)
+	assert.Equal(t, "# Team rules\n"+quoted+"\n## Notes\n", Remove(doc))
+}
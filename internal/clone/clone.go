@@ -18,6 +18,7 @@ type CloneManager struct {
 	copyManager      *CopyManager
 	replicator       *AgentReplicator
 	initializer      project.Initializer
+	strategies       map[CloneStrategy]StrategyDefinition // configured strategies
 }
 
 // CloneConfig defines the configuration for cloning
@@ -49,6 +50,11 @@ func NewCloneManager(fs afero.Fs) *CloneManager {
 	}
 }
 
+// SetStrategies sets strategies that override or extend the built-in ones
+func (m *CloneManager) SetStrategies(defs map[CloneStrategy]StrategyDefinition) {
+	m.strategies = defs
+}
+
 // Clone executes the complete clone workflow
 func (m *CloneManager) Clone(config CloneConfig) (*CloneResult, error) {
 	result := &CloneResult{}
@@ -61,6 +67,7 @@ func (m *CloneManager) Clone(config CloneConfig) (*CloneResult, error) {
 	fmt.Println()
 
 	m.strategySelector = NewStrategySelector(validationResult.SourcePath, m.fs)
+	m.strategySelector.SetStrategies(m.strategies)
 
 	strategy, itemsToCopy, err := m.strategySelector.SelectStrategy()
 	if err != nil {
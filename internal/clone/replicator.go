@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/afero"
 
 	"github.com/GarrickZ2/archie/internal/agent"
+	"github.com/GarrickZ2/archie/internal/version"
 )
 
 // AgentReplicator replicates agent setup from source to target
@@ -52,6 +53,11 @@ func (r *AgentReplicator) Replicate(opts ReplicationOptions) (*ReplicationResult
 
 	ctx := context.Background()
 
+	// Custom agents defined in the source project travel with it
+	if err := r.copyProjectAgents(opts.SourcePath, opts.TargetPath); err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to copy project agents: %w", err))
+	}
+
 	for _, agentState := range opts.SourceAgents {
 		// Check if agent is available in registry
 		available, err := r.verifyAgentAvailability(agentState.AgentName)
@@ -77,6 +83,26 @@ func (r *AgentReplicator) Replicate(opts ReplicationOptions) (*ReplicationResult
 	return result, nil
 }
 
+// copyProjectAgents copies the custom agents in the source's .archie/agents.json
+// to the target project and registers them
+func (r *AgentReplicator) copyProjectAgents(sourcePath, targetPath string) error {
+	configs, err := agent.NewProjectAgentStore(r.fs, sourcePath).Load()
+	if err != nil {
+		return err
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+
+	target := agent.NewProjectAgentStore(r.fs, targetPath)
+	for _, config := range configs {
+		if err := target.Put(config); err != nil {
+			return err
+		}
+	}
+	return target.Register()
+}
+
 // verifyAgentAvailability checks if an agent is available in the registry
 func (r *AgentReplicator) verifyAgentAvailability(agentName string) (bool, error) {
 	_, err := agent.Get(agentName)
@@ -95,15 +121,20 @@ func (r *AgentReplicator) replicateAgent(ctx context.Context, agentState agent.A
 		AgentType:   agentState.AgentName,
 		Options: agent.SetupOptions{
 			IncludeExamples: true,
+			EnabledCommands: agentState.EnabledCommands,
 		},
 	}
 
-	if err := r.setupper.Setup(ctx, setupConfig); err != nil {
+	report, err := r.setupper.SetupWithReport(ctx, setupConfig)
+	if err != nil {
 		return fmt.Errorf("agent setup failed: %w", err)
 	}
 
-	// Mark as initialized in target project
-	if err := r.stateManager.MarkInitialized(targetPath, agentState.AgentName, agentState.IsCustom); err != nil {
+	// Mark as initialized in target project, recording what this Archie installed
+	agentState.ArchieVersion = version.Get()
+	agentState.TemplateHash = report.TemplateHash
+	agentState.Files = report.Manifest
+	if err := r.stateManager.MarkInitialized(targetPath, agentState); err != nil {
 		return fmt.Errorf("failed to mark agent as initialized: %w", err)
 	}
 
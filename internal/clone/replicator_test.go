@@ -0,0 +1,48 @@
+package clone
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GarrickZ2/archie/internal/agent"
+)
+
+func TestAgentReplicator_CopyProjectAgents(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	config := agent.CustomAgentConfig{
+		Name:        "team-bot-clone-test",
+		AgentDoc:    "AGENTS.md",
+		CommandsDir: ".team/commands",
+		FileFormat:  "md",
+	}
+	require.NoError(t, agent.NewProjectAgentStore(fs, "/source").Put(config))
+
+	replicator := NewAgentReplicator(fs)
+	require.NoError(t, replicator.copyProjectAgents("/source", "/target"))
+
+	copied, err := agent.NewProjectAgentStore(fs, "/target").Load()
+	require.NoError(t, err)
+	require.Len(t, copied, 1)
+	assert.Equal(t, config.Name, copied[0].Name)
+	assert.Equal(t, config.CommandsDir, copied[0].CommandsDir)
+
+	registered, err := agent.Get(config.Name)
+	require.NoError(t, err, "copied agents must be registered so they can be set up in the clone")
+	customAgent, ok := registered.(*agent.CustomAgent)
+	require.True(t, ok)
+	assert.Equal(t, agent.ScopeProject, customAgent.Scope())
+}
+
+func TestAgentReplicator_CopyProjectAgents_None(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/source/.archie", 0755))
+
+	require.NoError(t, NewAgentReplicator(fs).copyProjectAgents("/source", "/target"))
+
+	exists, err := afero.Exists(fs, agent.ProjectAgentsPath("/target"))
+	require.NoError(t, err)
+	assert.False(t, exists, "no agents.json should be created when the source has no project agents")
+}
@@ -3,6 +3,7 @@ package clone
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/afero"
@@ -56,10 +57,14 @@ var strategies = map[CloneStrategy]StrategyDefinition{
 	},
 }
 
+// builtinOrder is the order built-in strategies are listed in
+var builtinOrder = []CloneStrategy{StrategyContext, StrategyLight, StrategyFull}
+
 // StrategySelector handles clone strategy selection via TUI
 type StrategySelector struct {
 	sourcePath string
 	fs         afero.Fs
+	strategies map[CloneStrategy]StrategyDefinition
 }
 
 // NewStrategySelector creates a new strategy selector
@@ -67,21 +72,64 @@ func NewStrategySelector(sourcePath string, fs afero.Fs) *StrategySelector {
 	if fs == nil {
 		fs = afero.NewOsFs()
 	}
+	available := make(map[CloneStrategy]StrategyDefinition, len(strategies))
+	for name, def := range strategies {
+		available[name] = def
+	}
 	return &StrategySelector{
 		sourcePath: sourcePath,
 		fs:         fs,
+		strategies: available,
+	}
+}
+
+// SetStrategies overrides built-in strategies or adds new ones (from clone.strategies in config)
+func (s *StrategySelector) SetStrategies(defs map[CloneStrategy]StrategyDefinition) {
+	for name, def := range defs {
+		def.Name = name
+		if def.Description == "" {
+			def.Description = s.strategies[name].Description
+		}
+		s.strategies[name] = def
 	}
 }
 
+// orderedStrategies returns built-in strategies first, then configured ones sorted by name
+func (s *StrategySelector) orderedStrategies() []CloneStrategy {
+	ordered := append([]CloneStrategy{}, builtinOrder...)
+	var extra []string
+	for name := range s.strategies {
+		if _, builtin := strategies[name]; !builtin {
+			extra = append(extra, string(name))
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		ordered = append(ordered, CloneStrategy(name))
+	}
+	return ordered
+}
+
 // SelectStrategy shows TUI for strategy selection
 // Returns selected strategy and items to copy
 func (s *StrategySelector) SelectStrategy() (CloneStrategy, []CloneItem, error) {
 	// Build strategy options
-	options := []string{
-		fmt.Sprintf("context  - %s", strategies[StrategyContext].Description),
-		fmt.Sprintf("light    - %s", strategies[StrategyLight].Description),
-		fmt.Sprintf("full     - %s", strategies[StrategyFull].Description),
-		fmt.Sprintf("custom   - Manually select items to copy"),
+	ordered := append(s.orderedStrategies(), StrategyCustom)
+	width := 0
+	for _, name := range ordered {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	options := make([]string, len(ordered))
+	optionToStrategy := make(map[string]CloneStrategy, len(ordered))
+	for i, name := range ordered {
+		description := "Manually select items to copy"
+		if name != StrategyCustom {
+			description = s.strategies[name].Description
+		}
+		options[i] = fmt.Sprintf("%-*s - %s", width+1, name, description)
+		optionToStrategy[options[i]] = name
 	}
 
 	// Show selection UI
@@ -97,17 +145,7 @@ func (s *StrategySelector) SelectStrategy() (CloneStrategy, []CloneItem, error)
 	}
 
 	// Parse selected strategy
-	var strategy CloneStrategy
-	switch {
-	case selected == options[0]:
-		strategy = StrategyContext
-	case selected == options[1]:
-		strategy = StrategyLight
-	case selected == options[2]:
-		strategy = StrategyFull
-	case selected == options[3]:
-		strategy = StrategyCustom
-	}
+	strategy := optionToStrategy[selected]
 
 	// Get items based on strategy
 	var items []CloneItem
@@ -119,7 +157,7 @@ func (s *StrategySelector) SelectStrategy() (CloneStrategy, []CloneItem, error)
 			return "", nil, err
 		}
 	} else {
-		items = strategyDefToItems(strategies[strategy])
+		items = strategyDefToItems(s.strategies[strategy])
 	}
 
 	return strategy, items, nil
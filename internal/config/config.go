@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"github.com/GarrickZ2/archie/internal/status"
+)
+
+// FileName is the config file name inside a .archie directory
+const FileName = "config.yaml"
+
+// Layer identifies where a setting comes from. Later layers override earlier ones.
+type Layer string
+
+const (
+	LayerDefault Layer = "default"
+	LayerUser    Layer = "user"    // ~/.archie/config.yaml
+	LayerProject Layer = "project" // <project>/.archie/config.yaml
+)
+
+// Config is the typed Archie configuration
+type Config struct {
+	Export  ExportConfig        `yaml:"export" json:"export"`
+	Status  status.StatusPolicy `yaml:"status" json:"status"`
+	Feature FeatureConfig       `yaml:"feature" json:"feature"`
+	Agent   AgentConfig         `yaml:"agent" json:"agent"`
+	Editor  string              `yaml:"editor" json:"editor"` // used when $EDITOR is not set
+	Clone   CloneConfig         `yaml:"clone" json:"clone"`
+}
+
+// ExportConfig configures archie export
+type ExportConfig struct {
+	// PathFormat is the default output path; {{date}} and {{project}} are expanded
+	PathFormat string `yaml:"path_format" json:"path_format"`
+}
+
+// FeatureConfig configures archie feature
+type FeatureConfig struct {
+	DependencyPolicy string `yaml:"dependency_policy" json:"dependency_policy"` // warn or refuse
+}
+
+// AgentConfig configures agent selection in archie init
+type AgentConfig struct {
+	Default string `yaml:"default" json:"default"` // agent preselected in the TUI
+}
+
+// CloneConfig configures archie clone
+type CloneConfig struct {
+	// Strategies overrides the built-in strategies (context, light, full) or adds new ones
+	Strategies map[string]CloneStrategy `yaml:"strategies" json:"strategies"`
+}
+
+// CloneStrategy lists the files and directories a clone strategy copies
+type CloneStrategy struct {
+	Description string   `yaml:"description" json:"description"`
+	Files       []string `yaml:"files" json:"files"`
+	Directories []string `yaml:"directories" json:"directories"`
+}
+
+// Default returns the built-in configuration
+func Default() *Config {
+	return &Config{
+		Export:  ExportConfig{PathFormat: "./archie-export-{{date}}.md"},
+		Status:  *status.DefaultStatusPolicy(),
+		Feature: FeatureConfig{DependencyPolicy: string(status.PolicyWarn)},
+		Editor:  "vim",
+	}
+}
+
+// Validate checks values that YAML decoding cannot
+func (c *Config) Validate() error {
+	if strings.TrimSpace(c.Export.PathFormat) == "" {
+		return fmt.Errorf("export.path_format must not be empty")
+	}
+	if err := c.Status.Validate(); err != nil {
+		return fmt.Errorf("status.%w", err)
+	}
+	if _, err := status.ParseReadinessPolicy(c.Feature.DependencyPolicy); err != nil {
+		return fmt.Errorf("feature.dependency_policy: %w", err)
+	}
+	for name, strategy := range c.Clone.Strategies {
+		if name == "custom" {
+			return fmt.Errorf("clone.strategies: 'custom' is reserved for manual selection")
+		}
+		if len(strategy.Files) == 0 && len(strategy.Directories) == 0 {
+			return fmt.Errorf("clone.strategies.%s: lists no files or directories", name)
+		}
+	}
+	return nil
+}
+
+// ExportPath expands export.path_format for a project; relative paths are
+// resolved against the project root so the result does not depend on the
+// directory archie runs in
+func (c *Config) ExportPath(projectPath string, now time.Time) string {
+	path := strings.NewReplacer(
+		"{{date}}", now.Format("2006-01-02"),
+		"{{project}}", filepath.Base(projectPath),
+	).Replace(c.Export.PathFormat)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectPath, path)
+	}
+	return path
+}
+
+// Loader reads and writes the user and project config files
+type Loader struct {
+	fs      afero.Fs
+	homeDir string
+}
+
+// NewLoader creates a config loader
+func NewLoader(fs afero.Fs) *Loader {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	homeDir, _ := os.UserHomeDir()
+	return &Loader{fs: fs, homeDir: homeDir}
+}
+
+// Load reads the configuration for a project from the real file system
+func Load(projectPath string) (*Config, error) {
+	return NewLoader(nil).Load(projectPath)
+}
+
+// Path returns the config file of a layer
+func (l *Loader) Path(layer Layer, projectPath string) (string, error) {
+	switch layer {
+	case LayerUser:
+		if l.homeDir == "" {
+			return "", fmt.Errorf("failed to get user home directory")
+		}
+		return filepath.Join(l.homeDir, ".archie", FileName), nil
+	case LayerProject:
+		return filepath.Join(projectPath, ".archie", FileName), nil
+	default:
+		return "", fmt.Errorf("layer %q has no config file", layer)
+	}
+}
+
+// Load merges the defaults, the user config and the project config
+func (l *Loader) Load(projectPath string) (*Config, error) {
+	cfg := Default()
+	for _, layer := range []Layer{LayerUser, LayerProject} {
+		data, path, err := l.read(layer, projectPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeInto(cfg, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// read returns the raw content of a layer's file (nil when it does not exist)
+func (l *Loader) read(layer Layer, projectPath string) ([]byte, string, error) {
+	path, err := l.Path(layer, projectPath)
+	if err != nil {
+		if layer == LayerUser {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	exists, err := afero.Exists(l.fs, path)
+	if err != nil {
+		return nil, path, fmt.Errorf("failed to check %s: %w", path, err)
+	}
+	if !exists {
+		return nil, path, nil
+	}
+
+	data, err := afero.ReadFile(l.fs, path)
+	if err != nil {
+		return nil, path, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return data, path, nil
+}
+
+// decodeInto decodes YAML over cfg; maps are merged, scalars and lists replaced
+func decodeInto(cfg *Config, data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
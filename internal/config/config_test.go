@@ -0,0 +1,102 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoader(t *testing.T, user, project string) *Loader {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	if user != "" {
+		require.NoError(t, afero.WriteFile(fs, "/home/.archie/config.yaml", []byte(user), 0644))
+	}
+	if project != "" {
+		require.NoError(t, afero.WriteFile(fs, "/p/.archie/config.yaml", []byte(project), 0644))
+	}
+	return &Loader{fs: fs, homeDir: "/home"}
+}
+
+func TestLoader_Load(t *testing.T) {
+	loader := newTestLoader(t, `
+editor: nano
+status:
+  stale_days:
+    default: 20
+    UNDER_REVIEW: 10
+`, `
+status:
+  stale_days:
+    UNDER_REVIEW: 5
+agent:
+  default: claude
+`)
+
+	cfg, err := loader.Load("/p")
+	require.NoError(t, err)
+	assert.Equal(t, "nano", cfg.Editor)
+	assert.Equal(t, "claude", cfg.Agent.Default)
+	assert.Equal(t, 20, cfg.Status.StaleDays["default"])
+	assert.Equal(t, 5, cfg.Status.StaleDays["UNDER_REVIEW"])
+	assert.Equal(t, "warn", cfg.Feature.DependencyPolicy)
+
+	entries, err := loader.List("/p")
+	require.NoError(t, err)
+	origins := make(map[string]Layer)
+	for _, entry := range entries {
+		origins[entry.Key] = entry.Origin
+	}
+	assert.Equal(t, LayerUser, origins["editor"])
+	assert.Equal(t, LayerProject, origins["status.stale_days.UNDER_REVIEW"])
+	assert.Equal(t, LayerDefault, origins["export.path_format"])
+}
+
+func TestLoader_LoadErrors(t *testing.T) {
+	_, err := newTestLoader(t, "", "edtor: nano\n").Load("/p")
+	assert.ErrorContains(t, err, "field edtor not found")
+
+	_, err = newTestLoader(t, "", "feature:\n  dependency_policy: ignore\n").Load("/p")
+	assert.ErrorContains(t, err, "feature.dependency_policy")
+}
+
+func TestLoader_Set(t *testing.T) {
+	loader := newTestLoader(t, "", "# team settings\neditor: nano\n")
+
+	path, err := loader.Set(LayerProject, "/p", "status.stale_days.UNDER_REVIEW", "5")
+	require.NoError(t, err)
+	assert.Equal(t, "/p/.archie/config.yaml", path)
+
+	data, err := afero.ReadFile(loader.fs, path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# team settings")
+	assert.Contains(t, string(data), "editor: nano")
+
+	value, origin, err := loader.Get("/p", "status.stale_days.UNDER_REVIEW")
+	require.NoError(t, err)
+	assert.Equal(t, "5", value)
+	assert.Equal(t, LayerProject, origin)
+
+	_, err = loader.Set(LayerProject, "/p", "status.stale_days.REVIEWING", "5")
+	assert.Error(t, err)
+
+	_, err = loader.Set(LayerUser, "/p", "editor", "code")
+	require.NoError(t, err)
+	value, origin, err = loader.Get("/p", "editor")
+	require.NoError(t, err)
+	assert.Equal(t, "nano", value)
+	assert.Equal(t, LayerProject, origin)
+}
+
+func TestConfig_ExportPath(t *testing.T) {
+	cfg := Default()
+	cfg.Export.PathFormat = "./exports/{{project}}-{{date}}.md"
+	now := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "/work/shop/exports/shop-2024-03-05.md", cfg.ExportPath("/work/shop", now))
+
+	cfg.Export.PathFormat = "/tmp/{{project}}.md"
+	assert.Equal(t, "/tmp/shop.md", cfg.ExportPath("/work/shop", now))
+}
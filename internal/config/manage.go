@@ -0,0 +1,295 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// Entry is one effective setting and the layer it comes from
+type Entry struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value" yaml:"value"`
+	Origin Layer  `json:"origin" yaml:"origin"`
+}
+
+// List returns every effective setting as dotted keys, sorted by key
+func (l *Loader) List(projectPath string) ([]Entry, error) {
+	effective, err := l.effectiveTree(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := make(map[string]interface{})
+	flatten("", effective, leaves)
+
+	keys := make([]string, 0, len(leaves))
+	for key := range leaves {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]Entry, 0, len(keys))
+	for _, key := range keys {
+		origin, err := l.origin(projectPath, key)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{Key: key, Value: formatValue(leaves[key]), Origin: origin})
+	}
+	return entries, nil
+}
+
+// Get returns the effective value of a dotted key; sections are returned as YAML
+func (l *Loader) Get(projectPath, key string) (string, Layer, error) {
+	effective, err := l.effectiveTree(projectPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	value, ok := lookup(effective, splitKey(key))
+	if !ok {
+		return "", "", fmt.Errorf("unknown config key %q", key)
+	}
+
+	origin, err := l.origin(projectPath, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	if section, isSection := value.(map[string]interface{}); isSection {
+		data, err := encodeYAML(section)
+		if err != nil {
+			return "", "", err
+		}
+		return strings.TrimRight(string(data), "\n"), origin, nil
+	}
+	return formatValue(value), origin, nil
+}
+
+// Set writes a dotted key to the user or project config file, keeping comments
+// and other settings. The value is parsed as YAML, so "5" is a number and
+// "[a, b]" a list. Returns the file written.
+func (l *Loader) Set(layer Layer, projectPath, key, value string) (string, error) {
+	path, err := l.Path(layer, projectPath)
+	if err != nil {
+		return "", err
+	}
+	segments := splitKey(key)
+	if len(segments) == 0 {
+		return "", fmt.Errorf("config key must not be empty")
+	}
+
+	data, _, err := l.read(layer, projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("%s: top level must be a mapping", path)
+	}
+
+	valueNode, err := parseValue(value)
+	if err != nil {
+		return "", err
+	}
+	setNode(doc.Content[0], segments, valueNode)
+
+	updated, err := encodeYAML(&doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	// Validate the whole stack with the updated layer before writing
+	if err := l.validateWith(layer, projectPath, updated); err != nil {
+		return "", fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	if err := l.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := afero.WriteFile(l.fs, path, updated, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// validateWith loads every layer, replacing one with candidate content
+func (l *Loader) validateWith(replaced Layer, projectPath string, candidate []byte) error {
+	cfg := Default()
+	for _, layer := range []Layer{LayerUser, LayerProject} {
+		data := candidate
+		if layer != replaced {
+			var err error
+			if data, _, err = l.read(layer, projectPath); err != nil {
+				return err
+			}
+		}
+		if err := decodeInto(cfg, data); err != nil {
+			return err
+		}
+	}
+	return cfg.Validate()
+}
+
+// effectiveTree returns the merged configuration as a generic tree
+func (l *Loader) effectiveTree(projectPath string) (map[string]interface{}, error) {
+	cfg, err := l.Load(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tree := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// origin returns the highest layer that sets key (or one of its parents)
+func (l *Loader) origin(projectPath, key string) (Layer, error) {
+	segments := splitKey(key)
+	for _, layer := range []Layer{LayerProject, LayerUser} {
+		data, _, err := l.read(layer, projectPath)
+		if err != nil {
+			return "", err
+		}
+		tree := make(map[string]interface{})
+		if err := yaml.Unmarshal(data, &tree); err != nil {
+			return "", err
+		}
+		if _, ok := lookup(tree, segments); ok {
+			return layer, nil
+		}
+	}
+	return LayerDefault, nil
+}
+
+// splitKey splits a dotted key, ignoring empty segments
+func splitKey(key string) []string {
+	segments := []string{}
+	for _, segment := range strings.Split(key, ".") {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// lookup walks a generic tree along segments
+func lookup(tree map[string]interface{}, segments []string) (interface{}, bool) {
+	var current interface{} = tree
+	for _, segment := range segments {
+		section, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = section[segment]; !ok {
+			return nil, false
+		}
+	}
+	return current, len(segments) > 0
+}
+
+// flatten collects the leaves of a tree as dotted keys; empty sections are skipped
+func flatten(prefix string, value interface{}, out map[string]interface{}) {
+	section, ok := value.(map[string]interface{})
+	if !ok {
+		out[prefix] = value
+		return
+	}
+	for key, child := range section {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		flatten(key, child, out)
+	}
+}
+
+// formatValue renders a leaf value on one line
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// encodeYAML encodes with the two-space indentation used in Archie documents
+func encodeYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseValue parses a command-line value as a YAML node
+func parseValue(value string) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", value, err)
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	}
+	return doc.Content[0], nil
+}
+
+// setNode sets segments to value inside a mapping node, creating sections as needed
+func setNode(mapping *yaml.Node, segments []string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != segments[0] {
+			continue
+		}
+		if len(segments) == 1 {
+			mapping.Content[i+1] = value
+			return
+		}
+		child := mapping.Content[i+1]
+		if child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			mapping.Content[i+1] = child
+		}
+		setNode(child, segments[1:], value)
+		return
+	}
+
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: segments[0]}
+	if len(segments) == 1 {
+		mapping.Content = append(mapping.Content, keyNode, value)
+		return
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapping.Content = append(mapping.Content, keyNode, child)
+	setNode(child, segments[1:], value)
+}
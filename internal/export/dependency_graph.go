@@ -2,6 +2,7 @@ package export
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/GarrickZ2/archie/internal/status"
@@ -38,23 +39,29 @@ func (g *DependencyGraphGenerator) Generate() string {
 		return ""
 	}
 
+	cycles := g.buildCycleGraph()
+
 	var content strings.Builder
 
 	content.WriteString("## Dependency Graph\n\n")
-	content.WriteString(g.formatMermaid(graph))
+	content.WriteString(g.formatMermaid(graph, cycles))
 
 	// Add legend
 	content.WriteString("\n**Legend:**\n\n")
 	content.WriteString("- `→` indicates dependency (feature depends on target)\n")
 
-	featureList := make([]string, 0, len(graph))
-	for key := range graph {
-		featureList = append(featureList, key)
-	}
+	featureList := sortedKeys(graph)
 	if len(featureList) > 0 {
 		content.WriteString(fmt.Sprintf("- **Features**: %s\n", strings.Join(featureList, ", ")))
 	}
 
+	if len(cycles.CircularDeps) > 0 {
+		content.WriteString("- Red edges and nodes are part of a circular dependency:\n")
+		for _, cycle := range cycles.CircularDeps {
+			content.WriteString(fmt.Sprintf("  - %s → %s\n", strings.Join(cycle, " → "), cycle[0]))
+		}
+	}
+
 	content.WriteString("\n")
 
 	return content.String()
@@ -80,6 +87,7 @@ func (g *DependencyGraphGenerator) buildGraph() map[string]*DependencyNode {
 
 		// Only add to graph if it has dependencies
 		if len(node.Dependencies) > 0 {
+			sort.Strings(node.Dependencies)
 			graph[feature.Key] = node
 		}
 	}
@@ -87,15 +95,49 @@ func (g *DependencyGraphGenerator) buildGraph() map[string]*DependencyNode {
 	return graph
 }
 
-// formatMermaid formats the graph as Mermaid syntax
-func (g *DependencyGraphGenerator) formatMermaid(graph map[string]*DependencyNode) string {
+// buildCycleGraph builds the status dependency graph used to find circular dependencies
+func (g *DependencyGraphGenerator) buildCycleGraph() *status.DependencyGraph {
+	features := make([]status.Feature, 0, len(g.features))
+	for _, detail := range g.features {
+		deps := make(map[string]string)
+		for depKey, reason := range detail.FeatureDependencies {
+			if depKey != "" && depKey != "<feature-key>" {
+				deps[depKey] = reason
+			}
+		}
+		features = append(features, status.Feature{
+			Name:         detail.Key,
+			Status:       detail.Status,
+			Dependencies: deps,
+		})
+	}
+
+	return status.BuildDependencyGraph(features)
+}
+
+// sortedKeys returns the graph keys in alphabetical order
+func sortedKeys(graph map[string]*DependencyNode) []string {
+	keys := make([]string, 0, len(graph))
+	for key := range graph {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatMermaid formats the graph as Mermaid syntax, highlighting edges that form a cycle
+func (g *DependencyGraphGenerator) formatMermaid(graph map[string]*DependencyNode, cycles *status.DependencyGraph) string {
 	var content strings.Builder
 
 	content.WriteString("```mermaid\n")
 	content.WriteString("graph LR\n")
 
-	// Add edges
-	for key, node := range graph {
+	// Add edges in a stable order so linkStyle indices match
+	edgeIndex := 0
+	cycleEdges := []string{}
+	for _, key := range sortedKeys(graph) {
+		node := graph[key]
+
 		// Format feature key for Mermaid (replace hyphens with underscores)
 		featureID := strings.ReplaceAll(key, "-", "_")
 
@@ -104,7 +146,25 @@ func (g *DependencyGraphGenerator) formatMermaid(graph map[string]*DependencyNod
 			dependencyID := strings.ReplaceAll(dependency, "-", "_")
 			content.WriteString(fmt.Sprintf("    %s[\"%s\"] --> %s[\"%s\"]\n",
 				dependencyID, dependency, featureID, key))
+
+			if cycles.InCycle(key, dependency) {
+				cycleEdges = append(cycleEdges, fmt.Sprintf("%d", edgeIndex))
+			}
+			edgeIndex++
+		}
+	}
+
+	if len(cycleEdges) > 0 {
+		cycleNodes := []string{}
+		for _, component := range cycles.Components {
+			for _, key := range component {
+				cycleNodes = append(cycleNodes, strings.ReplaceAll(key, "-", "_"))
+			}
 		}
+
+		content.WriteString("    classDef cycle fill:#ffebee,stroke:#e53935,stroke-width:2px\n")
+		content.WriteString(fmt.Sprintf("    class %s cycle\n", strings.Join(cycleNodes, ",")))
+		content.WriteString(fmt.Sprintf("    linkStyle %s stroke:#e53935,stroke-width:2px\n", strings.Join(cycleEdges, ",")))
 	}
 
 	content.WriteString("```\n")
@@ -20,6 +20,7 @@ type ExportManager struct {
 	flagTOC        bool
 	flagStats      bool
 	flagDepGraph   bool
+	flagTimeline   bool
 
 	// Components
 	selector  *DocumentSelector
@@ -53,6 +54,16 @@ func (m *ExportManager) SetFlags(outputPath string, toc, stats, depGraph bool) {
 	m.flagDepGraph = depGraph
 }
 
+// SetDefaultOutputPath sets the output path suggested when no --output flag is given
+func (m *ExportManager) SetDefaultOutputPath(path string) {
+	m.selector.defaultPath = path
+}
+
+// SetTimeline enables the timeline section (off by default)
+func (m *ExportManager) SetTimeline(timeline bool) {
+	m.flagTimeline = timeline
+}
+
 // Export executes the export workflow
 func (m *ExportManager) Export() (*ExportResult, error) {
 	// Step 1: Validate project structure
@@ -67,6 +78,7 @@ func (m *ExportManager) Export() (*ExportResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("selection failed: %w", err)
 	}
+	config.GenerateTimeline = m.flagTimeline
 
 	// Step 3: Collect documents
 	ui.ShowStep(3, 5, "Collecting documents...")
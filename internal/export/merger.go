@@ -7,6 +7,8 @@ import (
 	"github.com/spf13/afero"
 
 	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/tasks"
+	"github.com/GarrickZ2/archie/internal/timeline"
 )
 
 // DocumentMerger merges all documents into single markdown file
@@ -52,6 +54,15 @@ func (m *DocumentMerger) Merge(config *ExportConfig, documents []*ExportedDocume
 		}
 	}
 
+	// Add timeline if enabled and we have features
+	if config.GenerateTimeline && len(config.IncludeFeatures) > 0 {
+		timelineContent := m.generateTimeline(config)
+		if timelineContent != "" {
+			mainContent.WriteString(timelineContent)
+			mainContent.WriteString(m.formatter.CreateSeparator())
+		}
+	}
+
 	// Step 3: Add root documents
 	if len(config.IncludeRoot) > 0 {
 		mainContent.WriteString("# Documentation\n\n")
@@ -204,3 +215,20 @@ func (m *DocumentMerger) generateDependencyGraph(config *ExportConfig) string {
 	depGraphGen := NewDependencyGraphGenerator(featureDetails)
 	return depGraphGen.Generate()
 }
+
+// generateTimeline generates the timeline (Mermaid gantt) for the selected features
+func (m *DocumentMerger) generateTimeline(config *ExportConfig) string {
+	fs := afero.NewOsFs()
+	taskList, err := tasks.NewParser(fs).ParseProject(config.ProjectPath)
+	if err != nil {
+		return ""
+	}
+
+	features, err := status.NewParser(fs).ParseFeaturesDir(config.ProjectPath)
+	if err != nil {
+		return ""
+	}
+
+	result := timeline.Build(taskList.Select(config.IncludeFeatures), status.BuildDependencyGraph(features))
+	return result.Markdown()
+}
@@ -17,6 +17,7 @@ type DocumentSelector struct {
 	projectPath string
 	fs          afero.Fs
 	parser      *status.Parser
+	defaultPath string // suggested output path when --output is not given
 }
 
 // NewDocumentSelector creates a new document selector
@@ -227,6 +228,9 @@ func (s *DocumentSelector) selectAdditionalOptions(config *ExportConfig) (includ
 func (s *DocumentSelector) confirmOutputPath(flagPath string) (string, error) {
 	// Generate default path
 	defaultPath := flagPath
+	if defaultPath == "" {
+		defaultPath = s.defaultPath
+	}
 	if defaultPath == "" {
 		defaultPath = fmt.Sprintf("./archie-export-%s.md", time.Now().Format("2006-01-02"))
 	}
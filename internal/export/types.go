@@ -22,6 +22,7 @@ type ExportConfig struct {
 	GenerateTOC      bool     // Generate table of contents
 	GenerateDepGraph bool     // Generate dependency graph
 	GenerateStats    bool     // Generate status statistics
+	GenerateTimeline bool     // Generate timeline (Mermaid gantt) from tasks.md
 }
 
 // ExportedDocument represents a collected document
@@ -0,0 +1,141 @@
+// Package graph finds cycles in dependency graphs keyed by name, such as
+// feature dependencies and task dependencies.
+package graph
+
+import "sort"
+
+// MaxCycles caps the number of cycles Cycles lists, since the number of
+// elementary cycles can grow exponentially with the size of a component
+const MaxCycles = 100
+
+// CyclicComponents returns the strongly connected components of the graph that
+// contain a cycle: more than one node, or a node with an edge to itself
+// (Tarjan's algorithm). edges maps a node to the nodes it depends on; a node
+// without edges of its own can never be part of a cycle. Each component is
+// sorted and the components are ordered by their first node.
+func CyclicComponents(edges map[string][]string) [][]string {
+	nodes := make([]string, 0, len(edges))
+	for node := range edges {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	counter := 0
+	indices := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	components := [][]string{}
+
+	var connect func(node string)
+	connect = func(node string) {
+		indices[node] = counter
+		lowLink[node] = counter
+		counter++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, dep := range edges[node] {
+			if _, visited := indices[dep]; !visited {
+				connect(dep)
+				lowLink[node] = min(lowLink[node], lowLink[dep])
+			} else if onStack[dep] {
+				lowLink[node] = min(lowLink[node], indices[dep])
+			}
+		}
+
+		if lowLink[node] != indices[node] {
+			return
+		}
+
+		// node is the root of a component: pop it off the stack
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+		if len(component) > 1 || hasEdge(edges, node, node) {
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range nodes {
+		if _, visited := indices[node]; !visited {
+			connect(node)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
+// Cycles returns the elementary cycles within the given components (as returned
+// by CyclicComponents), up to MaxCycles. Each cycle starts at its smallest node,
+// so it is listed once.
+func Cycles(edges map[string][]string, components [][]string) [][]string {
+	cycles := [][]string{}
+
+	for _, component := range components {
+		members := make(map[string]bool, len(component))
+		for _, node := range component {
+			members[node] = true
+		}
+
+		for _, start := range component {
+			path := []string{start}
+			onPath := map[string]bool{start: true}
+
+			var walk func(node string) bool
+			walk = func(node string) bool {
+				for _, dep := range edges[node] {
+					// Only nodes of the component not before start
+					if !members[dep] || dep < start {
+						continue
+					}
+					if dep == start {
+						cycles = append(cycles, append([]string{}, path...))
+						if len(cycles) >= MaxCycles {
+							return false
+						}
+						continue
+					}
+					if onPath[dep] {
+						continue
+					}
+					onPath[dep] = true
+					path = append(path, dep)
+					if !walk(dep) {
+						return false
+					}
+					path = path[:len(path)-1]
+					onPath[dep] = false
+				}
+				return true
+			}
+
+			if !walk(start) {
+				return cycles
+			}
+		}
+	}
+
+	return cycles
+}
+
+// hasEdge reports whether from depends on to
+func hasEdge(edges map[string][]string, from, to string) bool {
+	for _, dep := range edges[from] {
+		if dep == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCyclicComponents(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b"},
+		"b": {"c", "missing"},
+		"c": {"a"},
+		"d": {"d"},
+		"e": {"a"},
+	}
+
+	// e depends on the cycle but is not on it; missing has no edges of its own
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d"}}, CyclicComponents(edges))
+	assert.Empty(t, CyclicComponents(map[string][]string{"a": {"b"}}))
+}
+
+func TestCycles(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"c"},
+		"c": {"a"},
+		"d": {"d"},
+	}
+
+	// Overlapping cycles in one component are listed separately, each from its smallest node
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"a", "c"}, {"d"}}, Cycles(edges, CyclicComponents(edges)))
+}
+
+func TestCycles_Limit(t *testing.T) {
+	// A complete graph on 8 nodes has far more than MaxCycles elementary cycles
+	edges := make(map[string][]string)
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			if i != j {
+				edges[fmt.Sprint(i)] = append(edges[fmt.Sprint(i)], fmt.Sprint(j))
+			}
+		}
+	}
+
+	assert.Len(t, Cycles(edges, CyclicComponents(edges)), MaxCycles)
+}
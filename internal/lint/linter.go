@@ -0,0 +1,277 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/GarrickZ2/archie/resources"
+)
+
+// Issue is a single schema violation in a workspace file
+type Issue struct {
+	File    string // path relative to the project root
+	Line    int
+	Message string
+}
+
+// String formats the issue as file:line: message
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+}
+
+// Result contains the outcome of a lint run
+type Result struct {
+	CheckedFiles []string
+	Issues       []Issue
+}
+
+// Linter validates workspace documents against the shipped schema templates
+type Linter struct {
+	fs      afero.Fs
+	rules   []Rule
+	schemas map[string]*Section
+}
+
+// NewLinter creates a new linter
+func NewLinter(fs afero.Fs) (*Linter, error) {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	rules, err := LoadRules()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Linter{
+		fs:      fs,
+		rules:   rules,
+		schemas: make(map[string]*Section),
+	}, nil
+}
+
+// Lint checks every workspace document under projectPath that has a schema
+func (l *Linter) Lint(projectPath string) (*Result, error) {
+	var files []string
+
+	err := afero.Walk(l.fs, projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip hidden directories (.archie, .git, agent folders)
+		if info.IsDir() {
+			if path != projectPath && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) == ".md" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk project: %w", err)
+	}
+
+	return l.LintFiles(projectPath, files)
+}
+
+// LintFiles checks the given files. Files without a matching schema are skipped.
+func (l *Linter) LintFiles(projectPath string, files []string) (*Result, error) {
+	result := &Result{
+		CheckedFiles: []string{},
+		Issues:       []Issue{},
+	}
+
+	for _, file := range files {
+		fullPath := file
+		if !filepath.IsAbs(fullPath) {
+			fullPath = filepath.Join(projectPath, file)
+		}
+
+		relPath, err := filepath.Rel(projectPath, fullPath)
+		if err != nil || strings.HasPrefix(relPath, "..") {
+			continue
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		rule, ok := MatchRule(l.rules, relPath)
+		if !ok {
+			continue
+		}
+
+		schema, err := l.loadSchema(rule.Schema)
+		if err != nil {
+			// Schema referenced by schema.md but not shipped
+			continue
+		}
+
+		content, err := afero.ReadFile(l.fs, fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", relPath, err)
+		}
+
+		// Empty files have not been written yet
+		if strings.TrimSpace(string(content)) == "" {
+			continue
+		}
+
+		result.CheckedFiles = append(result.CheckedFiles, relPath)
+		result.Issues = append(result.Issues, CheckDocument(relPath, string(content), schema)...)
+	}
+
+	sort.SliceStable(result.Issues, func(i, j int) bool {
+		if result.Issues[i].File != result.Issues[j].File {
+			return result.Issues[i].File < result.Issues[j].File
+		}
+		return result.Issues[i].Line < result.Issues[j].Line
+	})
+
+	return result, nil
+}
+
+// loadSchema loads and caches a parsed schema template
+func (l *Linter) loadSchema(name string) (*Section, error) {
+	if schema, ok := l.schemas[name]; ok {
+		return schema, nil
+	}
+
+	content, err := resources.GetSchemaTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := parseSchemaOutline(content)
+	l.schemas[name] = schema
+	return schema, nil
+}
+
+// CheckDocument compares a document against a parsed schema outline
+func CheckDocument(relPath, content string, schema *Section) []Issue {
+	doc := ParseOutline(content)
+	c := &checker{file: relPath}
+
+	// Documents without a title: compare against the title's sections directly
+	if len(schema.Children) == 1 && schema.Children[0].Level == 1 && !hasLevel(doc.Children, 1) {
+		title := schema.Children[0]
+		c.add(1, fmt.Sprintf("missing title %q", title.Heading()))
+		c.checkSection(title, doc)
+		return c.issues
+	}
+
+	c.checkChildren(schema, doc, true)
+	return c.issues
+}
+
+// checker accumulates issues for a single document
+type checker struct {
+	file   string
+	issues []Issue
+}
+
+func (c *checker) add(line int, message string) {
+	c.issues = append(c.issues, Issue{File: c.file, Line: line, Message: message})
+}
+
+// checkSection checks fields and children of a matched section pair
+func (c *checker) checkSection(schema, doc *Section) {
+	present := make(map[string]bool)
+	for _, field := range doc.Fields {
+		present[normalizeTitle(field)] = true
+	}
+	for _, field := range schema.Fields {
+		if !present[normalizeTitle(field)] {
+			if doc.Level == 0 {
+				c.add(doc.Line, fmt.Sprintf("missing field %q", field))
+			} else {
+				c.add(doc.Line, fmt.Sprintf("section %q is missing field %q", doc.Heading(), field))
+			}
+		}
+	}
+
+	c.checkChildren(schema, doc, false)
+}
+
+// checkChildren matches document sub-sections to schema sub-sections and
+// reports missing, misordered and mis-levelled sections
+func (c *checker) checkChildren(schema, doc *Section, requireRepeatable bool) {
+	seen := make(map[*Section]bool)
+	lastIndex := -1
+	var lastSeen *Section
+
+	for _, docChild := range doc.Children {
+		index, schemaChild := findSchemaSection(schema.Children, docChild.Title)
+		if schemaChild == nil {
+			// Extra sections are allowed
+			continue
+		}
+
+		if docChild.Level != schemaChild.Level {
+			c.add(docChild.Line, fmt.Sprintf("heading level mismatch: expected %q, found %q",
+				strings.Repeat("#", schemaChild.Level)+" "+docChild.Title, docChild.Heading()))
+		}
+
+		if !schemaChild.IsRepeatable() {
+			if seen[schemaChild] {
+				c.add(docChild.Line, fmt.Sprintf("duplicate section %q", docChild.Heading()))
+			} else if index < lastIndex {
+				c.add(docChild.Line, fmt.Sprintf("section %q is out of order (expected before %q)",
+					docChild.Heading(), lastSeen.Heading()))
+			} else {
+				lastIndex = index
+				lastSeen = docChild
+			}
+		}
+		seen[schemaChild] = true
+
+		c.checkSection(schemaChild, docChild)
+	}
+
+	for _, schemaChild := range schema.Children {
+		if seen[schemaChild] {
+			continue
+		}
+		if schemaChild.IsRepeatable() && !requireRepeatable {
+			continue
+		}
+		if doc.Level == 0 {
+			c.add(doc.Line, fmt.Sprintf("missing section %q", schemaChild.Heading()))
+		} else {
+			c.add(doc.Line, fmt.Sprintf("missing section %q under %q", schemaChild.Heading(), doc.Heading()))
+		}
+	}
+}
+
+// findSchemaSection finds the schema section matching a document heading,
+// preferring fixed titles over placeholder patterns
+func findSchemaSection(sections []*Section, title string) (int, *Section) {
+	for i, section := range sections {
+		if !section.IsRepeatable() && section.matches(title) {
+			return i, section
+		}
+	}
+	for i, section := range sections {
+		if section.IsRepeatable() && section.matches(title) {
+			return i, section
+		}
+	}
+	return -1, nil
+}
+
+// hasLevel reports whether any section has the given heading level
+func hasLevel(sections []*Section, level int) bool {
+	for _, section := range sections {
+		if section.Level == level {
+			return true
+		}
+	}
+	return false
+}
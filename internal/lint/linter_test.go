@@ -0,0 +1,155 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validFeature = `# checkout-discount
+
+## Status
+- Value: NOT_REVIEWED
+- Owner: alice
+- Last Updated: 2024-01-15
+- Reason: new
+
+## Summary
+- One-liner: discounts at checkout
+- Background: users want discounts
+- User story: As a buyer I want discounts
+
+## Scope
+### In Scope
+- coupons
+
+### Out of Scope
+- gift cards
+
+## Requirements
+- R1: apply coupon
+
+## Non-Requirements
+- NR1: no loyalty points
+
+## Feature Dependencies
+
+## Acceptance Criteria
+- AC1: coupon reduces total
+
+## Design Artifacts
+- API: api/api.md#ApplyCoupon
+- Storage: storage.md#coupon
+- Workflow: workflow/checkout-discount/workflow.md
+- Metrics: metrics.md#checkout-discount
+- Spec: spec/checkout-discount.spec.md
+- Tasks: tasks.md#checkout-discount
+- Test Plan: testplan/checkout-discount.md
+
+## Changelog
+- 2024-01-15 (alice): created
+`
+
+func TestLoadRules_MapsSchemaIndex(t *testing.T) {
+	rules, err := LoadRules()
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"background.md":                 "background.md",
+		"features/checkout.md":          "feature.md",
+		"features/README.md":            "feature_readme.md",
+		"spec/checkout.spec.md":         "spec.md",
+		"workflow/checkout/workflow.md": "workflow.md",
+		"testplan/checkout.md":          "testplan.md",
+	}
+
+	for path, schema := range tests {
+		rule, ok := MatchRule(rules, path)
+		require.True(t, ok, "expected rule for %s", path)
+		assert.Equal(t, schema, rule.Schema, path)
+	}
+
+	_, ok := MatchRule(rules, "features/nested/checkout.md")
+	assert.False(t, ok)
+}
+
+func TestLinter_ValidFeature(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/features/checkout-discount.md", []byte(validFeature), 0644))
+
+	linter, err := NewLinter(fs)
+	require.NoError(t, err)
+
+	result, err := linter.Lint("/project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"features/checkout-discount.md"}, result.CheckedFiles)
+	assert.Empty(t, result.Issues)
+}
+
+func TestLinter_ReportsMissingAndMisordered(t *testing.T) {
+	content := strings.Replace(validFeature, "- Owner: alice\n", "", 1)
+	content = strings.Replace(content, "## Non-Requirements\n- NR1: no loyalty points\n", "", 1)
+	// Move Changelog before Summary
+	content = strings.Replace(content, "## Changelog\n- 2024-01-15 (alice): created\n", "", 1)
+	content = strings.Replace(content, "## Summary", "## Changelog\n- 2024-01-15 (alice): created\n\n## Summary", 1)
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/features/checkout-discount.md", []byte(content), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/project/tasks.md", []byte("   \n"), 0644))
+
+	linter, err := NewLinter(fs)
+	require.NoError(t, err)
+
+	result, err := linter.Lint("/project")
+	require.NoError(t, err)
+
+	var messages []string
+	for _, issue := range result.Issues {
+		assert.Equal(t, "features/checkout-discount.md", issue.File)
+		messages = append(messages, issue.String())
+	}
+
+	assert.Contains(t, messages, `features/checkout-discount.md:3: section "## Status" is missing field "Owner"`)
+	assert.Contains(t, messages, `features/checkout-discount.md:11: section "## Summary" is out of order (expected before "## Changelog")`)
+	assert.Contains(t, messages, `features/checkout-discount.md:1: missing section "## Non-Requirements" under "# checkout-discount"`)
+}
+
+func TestCheckDocument_RepeatableSections(t *testing.T) {
+	schema, err := NewLinter(afero.NewMemMapFs())
+	require.NoError(t, err)
+	tasksSchema, err := schema.loadSchema("tasks.md")
+	require.NoError(t, err)
+
+	content := `# Tasks
+
+## checkout-discount
+
+### T-1: add coupon table
+- Status: [ ] TODO
+- Owner: bob
+- ETA: 2024-02-01
+- Depends on: none
+- Links: storage.md
+- Description: table
+- Deliverable: migration
+
+### T-2: add api
+- Status: [ ] TODO
+- Owner: bob
+- ETA: 2024-02-03
+- Depends on: T-1
+- Links: api/api.md
+- Description: api
+
+#### Log
+- 2024-01-20 (bob): started
+`
+
+	issues := CheckDocument("tasks.md", content, tasksSchema)
+	require.Len(t, issues, 2)
+	assert.Equal(t, `tasks.md:5: missing section "#### Log" under "### T-1: add coupon table"`, issues[0].String())
+	assert.Equal(t, `tasks.md:14: section "### T-2: add api" is missing field "Deliverable"`, issues[1].String())
+}
@@ -0,0 +1,157 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	headingRegex = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	// fieldRegex matches top-level "- Label: value" list items
+	fieldRegex        = regexp.MustCompile("^-\\s+([^:<>\\[\\]`]+?):")
+	numberedItemRegex = regexp.MustCompile(`^[A-Z]{1,3}\d+$`)
+	placeholderRegex  = regexp.MustCompile(`<[^>]+>`)
+)
+
+const (
+	appendOnlyMarker = "<!-- ARCHIE:APPEND_ONLY -->"
+	appendEndMarker  = "<!-- ARCHIE:END -->"
+	datePlaceholder  = "YYYY-MM-DD"
+)
+
+// Section is a heading in a markdown document together with the
+// fields and sub-sections that belong to it
+type Section struct {
+	Level    int
+	Title    string
+	Line     int
+	Fields   []string
+	Children []*Section
+
+	// pattern is set for schema headings containing placeholders
+	// (e.g. "## <feature-key>"); such sections may repeat or be absent
+	pattern *regexp.Regexp
+}
+
+// IsRepeatable reports whether the section is a placeholder section
+func (s *Section) IsRepeatable() bool {
+	return s.pattern != nil
+}
+
+// Heading renders the section as a markdown heading
+func (s *Section) Heading() string {
+	return strings.Repeat("#", s.Level) + " " + s.Title
+}
+
+// matches reports whether a document heading title satisfies this schema section
+func (s *Section) matches(title string) bool {
+	if s.pattern != nil {
+		return s.pattern.MatchString(strings.TrimSpace(title))
+	}
+	return normalizeTitle(s.Title) == normalizeTitle(title)
+}
+
+// ParseOutline parses markdown content into a tree of sections.
+// The returned root has level 0 and holds the top-level headings.
+// Headings inside fenced code blocks are ignored, and fields inside
+// ARCHIE:APPEND_ONLY blocks are not collected.
+func ParseOutline(content string) *Section {
+	root := &Section{Level: 0, Line: 1}
+	stack := []*Section{root}
+
+	inFence := false
+	inAppendOnly := false
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		switch trimmed {
+		case appendOnlyMarker:
+			inAppendOnly = true
+			continue
+		case appendEndMarker:
+			inAppendOnly = false
+			continue
+		}
+
+		if matches := headingRegex.FindStringSubmatch(line); matches != nil {
+			section := &Section{
+				Level: len(matches[1]),
+				Title: matches[2],
+				Line:  i + 1,
+			}
+
+			// Pop until we find a shallower parent
+			for len(stack) > 1 && stack[len(stack)-1].Level >= section.Level {
+				stack = stack[:len(stack)-1]
+			}
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, section)
+			stack = append(stack, section)
+			inAppendOnly = false
+			continue
+		}
+
+		if inAppendOnly {
+			continue
+		}
+
+		if matches := fieldRegex.FindStringSubmatch(line); matches != nil {
+			label := strings.TrimSpace(matches[1])
+			if isFieldLabel(label) {
+				current := stack[len(stack)-1]
+				current.Fields = append(current.Fields, label)
+			}
+		}
+	}
+
+	return root
+}
+
+// parseSchemaOutline parses a schema template, compiling placeholder
+// headings into patterns
+func parseSchemaOutline(content string) *Section {
+	root := ParseOutline(content)
+	compilePatterns(root)
+	return root
+}
+
+// compilePatterns turns headings with placeholders into match patterns
+func compilePatterns(section *Section) {
+	for _, child := range section.Children {
+		if hasPlaceholder(child.Title) {
+			expr := regexp.QuoteMeta(child.Title)
+			expr = placeholderRegex.ReplaceAllString(expr, `.+`)
+			expr = strings.ReplaceAll(expr, datePlaceholder, `\d{4}-\d{2}-\d{2}`)
+			child.pattern = regexp.MustCompile(`(?i)^` + expr + `$`)
+		}
+		compilePatterns(child)
+	}
+}
+
+// hasPlaceholder reports whether a template string contains a placeholder
+func hasPlaceholder(s string) bool {
+	return placeholderRegex.MatchString(s) || strings.Contains(s, datePlaceholder)
+}
+
+// isFieldLabel filters out list items that are enumerations (R1, AC2)
+// or log entries rather than named fields
+func isFieldLabel(label string) bool {
+	if label == "" || hasPlaceholder(label) {
+		return false
+	}
+	return !numberedItemRegex.MatchString(label)
+}
+
+// normalizeTitle normalizes a heading title for comparison
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GarrickZ2/archie/resources"
+)
+
+// schemaRowRegex matches lookup table rows in schema.md:
+// | `features/<feature-key>.md` | `.archie/docs/schema/feature.md` |
+var schemaRowRegex = regexp.MustCompile("^\\|\\s*`([^`]+)`\\s*\\|\\s*`\\.archie/docs/schema/([^`]+)`\\s*\\|")
+
+// Rule maps a workspace file pattern to its schema template
+type Rule struct {
+	Pattern string // workspace path pattern as written in schema.md
+	Schema  string // schema template file name (e.g. "feature.md")
+
+	regex *regexp.Regexp
+}
+
+// Matches reports whether a slash-separated workspace path is covered by the rule
+func (r *Rule) Matches(relPath string) bool {
+	return r.regex.MatchString(relPath)
+}
+
+// LoadRules reads the schema lookup tables from the shipped schema.md.
+// Rules are returned in document order; the first matching rule wins.
+func LoadRules() ([]Rule, error) {
+	index, err := resources.GetSchemaIndex()
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(index)
+}
+
+// ParseRules parses schema lookup table rows from schema index content
+func ParseRules(index string) ([]Rule, error) {
+	var rules []Rule
+	for _, line := range strings.Split(index, "\n") {
+		matches := schemaRowRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		pattern := matches[1]
+		expr := regexp.QuoteMeta(pattern)
+		expr = placeholderRegex.ReplaceAllString(expr, `[^/]+`)
+
+		regex, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace pattern %q: %w", pattern, err)
+		}
+
+		rules = append(rules, Rule{
+			Pattern: pattern,
+			Schema:  matches[2],
+			regex:   regex,
+		})
+	}
+
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no schema lookup rules found")
+	}
+
+	return rules, nil
+}
+
+// MatchRule returns the first rule covering the given workspace path
+func MatchRule(rules []Rule, relPath string) (*Rule, bool) {
+	for i := range rules {
+		if rules[i].Matches(relPath) {
+			return &rules[i], true
+		}
+	}
+	return nil, false
+}
@@ -2,8 +2,10 @@ package project
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
+	"github.com/GarrickZ2/archie/internal/agentdoc"
 	"github.com/GarrickZ2/archie/resources"
 	"github.com/spf13/afero"
 )
@@ -105,21 +107,24 @@ func (d *DefaultInitializer) createProjectStructure(targetPath string) error {
 		}
 	}
 
-	// 2. Create files (AGENTS.md uses embedded content, others are empty)
+	// 2. Create files (AGENTS.md gets the Archie managed block, others are empty)
 	for _, file := range structure.Files {
 		fullPath := filepath.Join(targetPath, file)
 
-		// AGENTS.md: force overwrite; others: skip if exists
-		isAgentsDoc := file == "AGENTS.md"
-		if !isAgentsDoc {
-			// Check if file already exists
-			exists, err := afero.Exists(d.fs, fullPath)
-			if err != nil {
-				return fmt.Errorf("failed to check file %s: %w", fullPath, err)
-			}
-			if exists {
-				continue // Skip existing files
+		if file == "AGENTS.md" {
+			if err := d.writeAgentsDoc(fullPath); err != nil {
+				return err
 			}
+			continue
+		}
+
+		// Check if file already exists
+		exists, err := afero.Exists(d.fs, fullPath)
+		if err != nil {
+			return fmt.Errorf("failed to check file %s: %w", fullPath, err)
+		}
+		if exists {
+			continue // Skip existing files
 		}
 
 		// Create parent directory
@@ -128,14 +133,8 @@ func (d *DefaultInitializer) createProjectStructure(targetPath string) error {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 
-		// Determine file content
-		var content string
-		if isAgentsDoc {
-			content = GetAgentsMdContent()
-		}
-
-		// Create file (AGENTS.md overwrites, others are empty)
-		if err := afero.WriteFile(d.fs, fullPath, []byte(content), 0644); err != nil {
+		// Create empty file
+		if err := afero.WriteFile(d.fs, fullPath, []byte(""), 0644); err != nil {
 			return fmt.Errorf("failed to create file %s: %w", fullPath, err)
 		}
 	}
@@ -191,3 +190,22 @@ func (d *DefaultInitializer) checkDirectory(targetPath string) (bool, error) {
 
 	return hasArchie, nil
 }
+
+// writeAgentsDoc adds the Archie managed block to AGENTS.md, keeping the project's
+// own instructions. An existing block is left to agent setup, which refreshes it
+// without overwriting edits.
+func (d *DefaultInitializer) writeAgentsDoc(fullPath string) error {
+	data, err := afero.ReadFile(d.fs, fullPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read file %s: %w", fullPath, err)
+	}
+	if _, ok := agentdoc.Extract(string(data)); ok {
+		return nil
+	}
+
+	content := agentdoc.Merge(string(data), agentdoc.Block(GetAgentsMdContent()))
+	if err := afero.WriteFile(d.fs, fullPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create file %s: %w", fullPath, err)
+	}
+	return nil
+}
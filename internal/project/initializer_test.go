@@ -1,10 +1,13 @@
 package project
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/GarrickZ2/archie/internal/agentdoc"
 )
 
 func TestInitializer_Initialize_NewDirectory(t *testing.T) {
@@ -92,3 +95,34 @@ func TestNewInitializer_WithPartialConfig(t *testing.T) {
 	err := initializer.Initialize("/test/partial")
 	assert.NoError(t, err, "使用部分配置初始化应该成功")
 }
+
+func TestInitializer_WriteAgentsDoc(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/test/project/AGENTS.md", []byte("# Team rules\n"), 0644)
+	initializer := &DefaultInitializer{fs: fs}
+
+	// 已有的内容保留，Archie 的内容写在 managed block 中
+	assert.NoError(t, initializer.writeAgentsDoc("/test/project/AGENTS.md"))
+	data, err := afero.ReadFile(fs, "/test/project/AGENTS.md")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# Team rules\n\n"+agentdoc.Begin+"\n"), "AGENTS.md = %q", data)
+
+	// 已有 block 时不重复插入
+	assert.NoError(t, initializer.writeAgentsDoc("/test/project/AGENTS.md"))
+	again, err := afero.ReadFile(fs, "/test/project/AGENTS.md")
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(again))
+}
+
+func TestInitializer_WriteAgentsDoc_UnmarkedLegacy(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	// 旧版本 Archie 写入的 AGENTS.md 没有 markers
+	afero.WriteFile(fs, "/test/project/AGENTS.md", []byte(GetAgentsMdContent()), 0644)
+	initializer := &DefaultInitializer{fs: fs}
+
+	// 旧内容被替换为 managed block，而不是再追加一份
+	assert.NoError(t, initializer.writeAgentsDoc("/test/project/AGENTS.md"))
+	data, err := afero.ReadFile(fs, "/test/project/AGENTS.md")
+	assert.NoError(t, err)
+	assert.Equal(t, agentdoc.Block(GetAgentsMdContent()), string(data))
+}
@@ -0,0 +1,55 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// ErrProjectNotFound 从起始目录向上都没有找到 .archie
+var ErrProjectNotFound = errors.New("not inside an Archie project (no .archie directory found)")
+
+// RootFinder 从某个目录向上查找 Archie 项目根目录（包含 .archie 的目录），类似 git 查找 .git
+type RootFinder struct {
+	fs      afero.Fs
+	homeDir string // 用户目录下的 .archie 存放全局配置，不是项目
+}
+
+// NewRootFinder 创建项目根目录查找器
+func NewRootFinder(fs afero.Fs) *RootFinder {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	homeDir, _ := os.UserHomeDir()
+	return &RootFinder{fs: fs, homeDir: homeDir}
+}
+
+// Find 返回 start 本身或离它最近的包含 .archie 的上级目录
+// 找不到时返回 ErrProjectNotFound
+func (f *RootFinder) Find(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s: %w", start, err)
+	}
+
+	for {
+		if dir != f.homeDir {
+			hasArchie, err := afero.DirExists(f.fs, filepath.Join(dir, ".archie"))
+			if err != nil {
+				return "", err
+			}
+			if hasArchie {
+				return dir, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrProjectNotFound
+		}
+		dir = parent
+	}
+}
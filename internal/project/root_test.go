@@ -0,0 +1,36 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootFinder_Find(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/repo/docs/design/.archie", 0755))
+	require.NoError(t, fs.MkdirAll("/repo/docs/design/workflow/checkout", 0755))
+	require.NoError(t, fs.MkdirAll("/home/me/.archie", 0755))
+	require.NoError(t, fs.MkdirAll("/home/me/notes", 0755))
+
+	finder := &RootFinder{fs: fs, homeDir: "/home/me"}
+
+	// 从子目录向上找到最近的 .archie
+	root, err := finder.Find("/repo/docs/design/workflow/checkout")
+	require.NoError(t, err)
+	assert.Equal(t, "/repo/docs/design", root)
+
+	root, err = finder.Find("/repo/docs/design")
+	require.NoError(t, err)
+	assert.Equal(t, "/repo/docs/design", root)
+
+	// 项目之外找不到
+	_, err = finder.Find("/repo")
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+
+	// 用户目录下的 .archie 是全局配置，不算项目
+	_, err = finder.Find("/home/me/notes")
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+}
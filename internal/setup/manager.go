@@ -24,6 +24,7 @@ const (
 type Manager struct {
 	projectPath string
 	fs          afero.Fs
+	editor      string // $EDITOR 未设置时使用的编辑器
 }
 
 // NewManager 创建 setup manager
@@ -34,6 +35,11 @@ func NewManager(projectPath string) *Manager {
 	}
 }
 
+// SetEditor 设置 $EDITOR 未设置时使用的编辑器
+func (m *Manager) SetEditor(editor string) {
+	m.editor = editor
+}
+
 // ShowMainUI 显示主界面
 func (m *Manager) ShowMainUI() error {
 	for {
@@ -123,7 +129,7 @@ func (m *Manager) handleBackground() error {
 	}
 
 	// 在编辑器中打开
-	return OpenInEditor(backgroundPath)
+	return OpenInEditor(backgroundPath, m.editor)
 }
 
 // handleFeatures 处理 feature 管理
@@ -237,7 +243,7 @@ func (m *Manager) addNewFeature() error {
 	ui.ShowSuccess(fmt.Sprintf("Created feature: %s", featureKey))
 
 	// 在编辑器中打开
-	return OpenInEditor(featurePath)
+	return OpenInEditor(featurePath, m.editor)
 }
 
 // openFeature 打开已有的 feature
@@ -268,7 +274,7 @@ func (m *Manager) openFeature(featureName string) error {
 	}
 
 	// 在编辑器中打开
-	return OpenInEditor(featurePath)
+	return OpenInEditor(featurePath, m.editor)
 }
 
 // isFeaturesEmpty 检查 features 文件夹是否为空（完全没有 feature 文件）
@@ -9,9 +9,12 @@ import (
 	"github.com/spf13/afero"
 )
 
-// GetEditor 获取用户配置的编辑器，优先使用 $EDITOR，默认为 vim
-func GetEditor() string {
+// GetEditor 获取用户配置的编辑器，优先使用 $EDITOR，其次是 fallback（配置中的 editor），默认为 vim
+func GetEditor(fallback string) string {
 	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = fallback
+	}
 	if editor == "" {
 		editor = "vim"
 	}
@@ -19,8 +22,8 @@ func GetEditor() string {
 }
 
 // OpenInEditor 在编辑器中打开文件
-func OpenInEditor(filePath string) error {
-	editor := GetEditor()
+func OpenInEditor(filePath, fallback string) error {
+	editor := GetEditor(fallback)
 	cmd := exec.Command(editor, filePath)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
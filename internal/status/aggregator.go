@@ -7,25 +7,45 @@ import (
 
 // Summary 状态汇总信息
 type Summary struct {
-	TotalFeatures     int
-	StatusCounts      map[FeatureStatus]int
-	BlockedFeatures   []Feature
-	InProgressCount   int
-	CompletedCount    int
-	NotStartedCount   int
-	OverallProgress   int
-	FeaturesByStatus  map[FeatureStatus][]Feature
-	StaleFeatures     []Feature // 超过30天未更新的 features
+	TotalFeatures    int                         `json:"total_features" yaml:"total_features"`
+	StatusCounts     map[FeatureStatus]int       `json:"status_counts" yaml:"status_counts"`
+	BlockedFeatures  []Feature                   `json:"blocked_features" yaml:"blocked_features"`
+	InProgressCount  int                         `json:"in_progress_count" yaml:"in_progress_count"`
+	CompletedCount   int                         `json:"completed_count" yaml:"completed_count"`
+	NotStartedCount  int                         `json:"not_started_count" yaml:"not_started_count"`
+	OverallProgress  int                         `json:"overall_progress" yaml:"overall_progress"`
+	FeaturesByStatus map[FeatureStatus][]Feature `json:"-" yaml:"-"`
+	StaleFeatures    []Feature                   `json:"stale_features" yaml:"stale_features"` // 停留在当前状态超过阈值的 features
+	DaysInStatus     map[string]int              `json:"days_in_status" yaml:"days_in_status"` // feature-key -> 处于当前状态的天数（有状态历史时）
+	Violations       []PolicyViolation           `json:"violations" yaml:"violations"`         // 过期阈值和 WIP 限制的违规
 }
 
 // Aggregator 状态聚合器
 type Aggregator struct {
-	features []Feature
+	features  []Feature
+	histories map[string]*FeatureHistory
+	policy    *StatusPolicy
 }
 
 // NewAggregator 创建聚合器
 func NewAggregator(features []Feature) *Aggregator {
-	return &Aggregator{features: features}
+	return &Aggregator{features: features, policy: DefaultStatusPolicy()}
+}
+
+// SetPolicy 设置过期阈值和 WIP 限制（nil 表示默认策略）
+func (a *Aggregator) SetPolicy(policy *StatusPolicy) {
+	if policy == nil {
+		policy = DefaultStatusPolicy()
+	}
+	a.policy = policy
+}
+
+// SetHistories 设置状态历史，过期判断改用处于当前状态的时间而不是 Last Updated
+func (a *Aggregator) SetHistories(histories []*FeatureHistory) {
+	a.histories = make(map[string]*FeatureHistory, len(histories))
+	for _, history := range histories {
+		a.histories[history.Feature] = history
+	}
 }
 
 // Aggregate 聚合统计信息
@@ -36,6 +56,8 @@ func (a *Aggregator) Aggregate() *Summary {
 		BlockedFeatures:  []Feature{},
 		FeaturesByStatus: make(map[FeatureStatus][]Feature),
 		StaleFeatures:    []Feature{},
+		DaysInStatus:     make(map[string]int),
+		Violations:       []PolicyViolation{},
 	}
 
 	if len(a.features) == 0 {
@@ -70,12 +92,28 @@ func (a *Aggregator) Aggregate() *Summary {
 		// 累加进度
 		totalProgress += GetStatusProgress(status)
 
-		// 检查是否过期（超过30天未更新）
-		if feature.IsOld(30) {
+		// 检查是否过期（在当前状态停留超过该状态的阈值）
+		days := a.daysInStatus(feature)
+		if days >= 0 {
+			summary.DaysInStatus[feature.Name] = days
+		} else {
+			days = feature.DaysSinceUpdate()
+		}
+		if threshold := a.policy.StaleThreshold(status); threshold > 0 && days > threshold {
 			summary.StaleFeatures = append(summary.StaleFeatures, feature)
+			summary.Violations = append(summary.Violations, PolicyViolation{
+				Kind:     ViolationStale,
+				Subject:  feature.Name,
+				Status:   status,
+				Limit:    threshold,
+				Actual:   days,
+				Features: []string{feature.Name},
+			})
 		}
 	}
 
+	summary.Violations = append(summary.Violations, a.policy.checkWIPLimits(a.features)...)
+
 	// 计算总体进度
 	if len(a.features) > 0 {
 		summary.OverallProgress = totalProgress / len(a.features)
@@ -84,6 +122,14 @@ func (a *Aggregator) Aggregate() *Summary {
 	return summary
 }
 
+// daysInStatus 返回 feature 处于当前状态的天数；没有状态历史时返回 -1（回退到 Last Updated）
+func (a *Aggregator) daysInStatus(feature Feature) int {
+	if history, ok := a.histories[feature.Name]; ok {
+		return history.DaysInCurrentStatus()
+	}
+	return -1
+}
+
 // GetTopInsights 获取关键洞察
 func (s *Summary) GetTopInsights() []string {
 	insights := []string{}
@@ -117,10 +163,17 @@ func (s *Summary) GetTopInsights() []string {
 
 	// 5. 过期 features
 	if len(s.StaleFeatures) > 0 {
-		insights = append(insights, fmt.Sprintf("⏰ %d feature(s) haven't been updated in 30+ days", len(s.StaleFeatures)))
+		insights = append(insights, fmt.Sprintf("⏰ %d feature(s) have stayed in their status past the stale threshold", len(s.StaleFeatures)))
+	}
+
+	// 6. WIP 超限
+	for _, violation := range s.Violations {
+		if violation.Kind != ViolationStale {
+			insights = append(insights, "🚦 WIP limit exceeded: "+violation.String())
+		}
 	}
 
-	// 6. 设计阶段提醒
+	// 7. 设计阶段提醒
 	designPhaseCount := s.StatusCounts[StatusUnderDesign] + s.StatusCounts[StatusDesigned] + s.StatusCounts[StatusSpecReady]
 	if designPhaseCount > s.TotalFeatures/2 {
 		insights = append(insights, "💡 Most features are in design phase - good planning!")
@@ -0,0 +1,214 @@
+package status
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// BlockerFileName 记录 blockers 的工作区文件
+const BlockerFileName = "blocker.md"
+
+// Blocker 表示 blocker.md 中的一条记录
+// 格式: ## B-<id>: <title>，后跟 Feature / Owner / Opened / Resolved / Description 字段
+type Blocker struct {
+	ID          string `json:"id" yaml:"id"`
+	Title       string `json:"title" yaml:"title"`
+	Feature     string `json:"feature" yaml:"feature"`
+	Owner       string `json:"owner" yaml:"owner"`
+	Opened      string `json:"opened" yaml:"opened"`
+	Resolved    string `json:"resolved" yaml:"resolved"`
+	Description string `json:"description" yaml:"description"`
+	Line        int    `json:"line" yaml:"line"` // 标题所在行（从 1 开始）
+}
+
+// IsOpen blocker 是否尚未解决
+func (b *Blocker) IsOpen() bool {
+	return b.Resolved == ""
+}
+
+// AgeDays 返回 blocker 的天数：未解决时到 now，已解决时到解决日期
+// Opened 无法解析时返回 -1
+func (b *Blocker) AgeDays(now time.Time) int {
+	opened, err := time.Parse("2006-01-02", b.Opened)
+	if err != nil {
+		return -1
+	}
+
+	end := now
+	if !b.IsOpen() {
+		if resolved, err := time.Parse("2006-01-02", b.Resolved); err == nil {
+			end = resolved
+		}
+	}
+
+	days := int(end.Sub(opened).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+var (
+	blockerHeadingRegex = regexp.MustCompile(`^##\s+(B-[A-Za-z0-9][A-Za-z0-9_.-]*)\s*(?::\s*(.*))?$`)
+	blockerFieldRegex   = regexp.MustCompile(`^-\s+([A-Za-z]+):\s*(.*)$`)
+	dateValueRegex      = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+)
+
+// BlockerParser 解析 blocker.md
+type BlockerParser struct {
+	fs afero.Fs
+}
+
+// NewBlockerParser 创建 blocker 解析器
+func NewBlockerParser(fs afero.Fs) *BlockerParser {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &BlockerParser{fs: fs}
+}
+
+// ParseProject 解析项目根目录下的 blocker.md（文件不存在时返回空列表）
+func (p *BlockerParser) ParseProject(projectPath string) ([]Blocker, error) {
+	path := filepath.Join(projectPath, BlockerFileName)
+
+	exists, err := afero.Exists(p.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s: %w", BlockerFileName, err)
+	}
+	if !exists {
+		return []Blocker{}, nil
+	}
+
+	content, err := afero.ReadFile(p.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", BlockerFileName, err)
+	}
+
+	return ParseBlockers(string(content)), nil
+}
+
+// ParseBlockers 解析 blocker.md 内容
+func ParseBlockers(content string) []Blocker {
+	blockers := []Blocker{}
+	var current *Blocker
+
+	for i, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+
+		if strings.HasPrefix(line, "#") {
+			if current != nil {
+				blockers = append(blockers, *current)
+				current = nil
+			}
+			if matches := blockerHeadingRegex.FindStringSubmatch(line); matches != nil {
+				current = &Blocker{
+					ID:    matches[1],
+					Title: strings.TrimSpace(matches[2]),
+					Line:  i + 1,
+				}
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		matches := blockerFieldRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		value := strings.TrimSpace(matches[2])
+		if strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">") {
+			value = ""
+		}
+
+		switch strings.ToLower(matches[1]) {
+		case "feature":
+			current.Feature = strings.Trim(value, "`")
+		case "owner":
+			current.Owner = value
+		case "opened":
+			current.Opened = dateValueRegex.FindString(value)
+		case "resolved":
+			current.Resolved = dateValueRegex.FindString(value)
+		case "description":
+			current.Description = value
+		}
+	}
+
+	if current != nil {
+		blockers = append(blockers, *current)
+	}
+
+	return blockers
+}
+
+// OpenBlockers 返回未解决的 blockers，按打开时间从早到晚排序
+func OpenBlockers(blockers []Blocker) []Blocker {
+	open := []Blocker{}
+	for _, blocker := range blockers {
+		if blocker.IsOpen() {
+			open = append(open, blocker)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		return open[i].Opened < open[j].Opened
+	})
+	return open
+}
+
+// BlockerCheck blockers 与 feature 状态的交叉校验结果
+type BlockerCheck struct {
+	BlockedWithoutBlocker []string  `json:"blocked_without_blocker" yaml:"blocked_without_blocker"` // BLOCKED 但没有未解决的 blocker
+	OpenOnUnblocked       []Blocker `json:"open_on_unblocked" yaml:"open_on_unblocked"`             // 未解决的 blocker 指向非 BLOCKED 的 feature
+	UnknownFeature        []Blocker `json:"unknown_feature" yaml:"unknown_feature"`                 // 未解决的 blocker 指向不存在的 feature
+}
+
+// HasIssues 是否存在不一致
+func (c *BlockerCheck) HasIssues() bool {
+	return len(c.BlockedWithoutBlocker) > 0 || len(c.OpenOnUnblocked) > 0 || len(c.UnknownFeature) > 0
+}
+
+// CrossCheckBlockers 对比 blockers 和 features 的状态
+func CrossCheckBlockers(features []Feature, blockers []Blocker) *BlockerCheck {
+	check := &BlockerCheck{
+		BlockedWithoutBlocker: []string{},
+		OpenOnUnblocked:       []Blocker{},
+		UnknownFeature:        []Blocker{},
+	}
+
+	byKey := make(map[string]*Feature)
+	for i := range features {
+		byKey[features[i].Name] = &features[i]
+	}
+
+	hasOpen := make(map[string]bool)
+	for _, blocker := range OpenBlockers(blockers) {
+		hasOpen[blocker.Feature] = true
+
+		feature, exists := byKey[blocker.Feature]
+		switch {
+		case !exists:
+			check.UnknownFeature = append(check.UnknownFeature, blocker)
+		case feature.Status != StatusBlocked:
+			check.OpenOnUnblocked = append(check.OpenOnUnblocked, blocker)
+		}
+	}
+
+	for _, feature := range features {
+		if feature.Status == StatusBlocked && !hasOpen[feature.Name] {
+			check.BlockedWithoutBlocker = append(check.BlockedWithoutBlocker, feature.Name)
+		}
+	}
+	sort.Strings(check.BlockedWithoutBlocker)
+
+	return check
+}
@@ -0,0 +1,262 @@
+package status
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// NewBlocker 新建 blocker 的请求
+type NewBlocker struct {
+	Feature     string
+	Title       string
+	Description string
+	Owner       string
+	Author      string // 写入 Changelog 的作者
+	Date        time.Time
+}
+
+// BlockerResult blocker 新建或解决的结果
+type BlockerResult struct {
+	Blocker        Blocker
+	StatusChange   *StatusChangeResult // feature 状态发生变化时非空
+	ChangelogEntry string              // 状态未变化时追加的 Changelog 记录
+	StillBlockedBy []string            // 解决后该 feature 仍未解决的其他 blockers
+}
+
+// BlockerManager 维护 blocker.md，并同步 feature 的 BLOCKED 状态
+type BlockerManager struct {
+	fs      afero.Fs
+	parser  *BlockerParser
+	updater *StatusUpdater
+}
+
+// NewBlockerManager 创建 blocker 管理器
+func NewBlockerManager(fs afero.Fs) *BlockerManager {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &BlockerManager{
+		fs:      fs,
+		parser:  NewBlockerParser(fs),
+		updater: NewStatusUpdater(fs),
+	}
+}
+
+var blockerNumberRegex = regexp.MustCompile(`^B-(\d+)$`)
+
+// Add 在 blocker.md 末尾追加一条 blocker，并将 feature 置为 BLOCKED
+// 进入 BLOCKED 的 Changelog 记录保存了之前的状态，解决时据此恢复
+func (m *BlockerManager) Add(projectPath string, req NewBlocker) (*BlockerResult, error) {
+	if strings.TrimSpace(req.Title) == "" {
+		return nil, fmt.Errorf("a blocker title is required")
+	}
+	if req.Date.IsZero() {
+		req.Date = time.Now()
+	}
+
+	detail, err := NewDetailParser(m.fs).ParseFeatureDetail(projectPath, req.Feature)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers, err := m.parser.ParseProject(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	blocker := Blocker{
+		ID:          nextBlockerID(blockers),
+		Title:       strings.TrimSpace(req.Title),
+		Feature:     req.Feature,
+		Owner:       req.Owner,
+		Opened:      req.Date.Format("2006-01-02"),
+		Description: req.Description,
+	}
+
+	result := &BlockerResult{Blocker: blocker}
+	reason := fmt.Sprintf("%s %s", blocker.ID, blocker.Title)
+
+	// 先改状态：非法流转（如 FINISHED → BLOCKED）时不写 blocker.md
+	if detail.Status == StatusBlocked {
+		entry, err := m.updater.AppendChangelog(projectPath, req.Feature, req.Author, req.Date, "Blocker opened: "+reason)
+		if err != nil {
+			return nil, err
+		}
+		result.ChangelogEntry = entry
+	} else {
+		change, err := m.updater.SetStatus(projectPath, req.Feature, StatusChange{
+			To:     StatusBlocked,
+			Reason: reason,
+			Author: req.Author,
+			Date:   req.Date,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.StatusChange = change
+	}
+
+	if err := m.appendBlocker(projectPath, blocker); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Resolve 标记 blocker 已解决
+// 若该 feature 没有其他未解决的 blocker，恢复进入 BLOCKED 之前的状态
+func (m *BlockerManager) Resolve(projectPath, blockerID, author string, date time.Time) (*BlockerResult, error) {
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	blockers, err := m.parser.ParseProject(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocker *Blocker
+	for i := range blockers {
+		if strings.EqualFold(blockers[i].ID, blockerID) {
+			blocker = &blockers[i]
+			break
+		}
+	}
+	if blocker == nil {
+		return nil, fmt.Errorf("blocker '%s' not found in %s", blockerID, BlockerFileName)
+	}
+	if !blocker.IsOpen() {
+		return nil, fmt.Errorf("blocker %s was already resolved on %s", blocker.ID, blocker.Resolved)
+	}
+
+	blocker.Resolved = date.Format("2006-01-02")
+	if err := m.writeResolved(projectPath, *blocker); err != nil {
+		return nil, err
+	}
+
+	result := &BlockerResult{Blocker: *blocker}
+	for _, other := range OpenBlockers(blockers) {
+		if other.Feature == blocker.Feature && other.ID != blocker.ID {
+			result.StillBlockedBy = append(result.StillBlockedBy, other.ID)
+		}
+	}
+
+	detail, err := NewDetailParser(m.fs).ParseFeatureDetail(projectPath, blocker.Feature)
+	if err != nil {
+		// blocker 指向不存在的 feature：只更新 blocker.md
+		return result, nil
+	}
+
+	description := fmt.Sprintf("Blocker resolved: %s %s", blocker.ID, blocker.Title)
+	if detail.Status != StatusBlocked || len(result.StillBlockedBy) > 0 {
+		entry, err := m.updater.AppendChangelog(projectPath, blocker.Feature, author, date, description)
+		if err != nil {
+			return nil, err
+		}
+		result.ChangelogEntry = entry
+		return result, nil
+	}
+
+	change, err := m.updater.SetStatus(projectPath, blocker.Feature, StatusChange{
+		To:     StatusBeforeBlocked(detail.Changelog),
+		Reason: description,
+		Author: author,
+		Date:   date,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("blocker %s resolved, but the feature status could not be restored: %w", blocker.ID, err)
+	}
+	result.StatusChange = change
+
+	return result, nil
+}
+
+// appendBlocker 以 schema 格式在 blocker.md 末尾追加一条记录
+func (m *BlockerManager) appendBlocker(projectPath string, blocker Blocker) error {
+	path := filepath.Join(projectPath, BlockerFileName)
+
+	var content string
+	if data, err := afero.ReadFile(m.fs, path); err == nil {
+		content = strings.TrimRight(string(data), "\n")
+	}
+	if strings.TrimSpace(content) == "" {
+		content = "# Blockers"
+	}
+
+	entry := []string{
+		"",
+		fmt.Sprintf("## %s: %s", blocker.ID, blocker.Title),
+		fmt.Sprintf("- Feature: `%s`", blocker.Feature),
+		formatField("Owner", blocker.Owner),
+		formatField("Opened", blocker.Opened),
+		formatField("Resolved", blocker.Resolved),
+		formatField("Description", blocker.Description),
+	}
+
+	content += "\n" + strings.Join(entry, "\n") + "\n"
+	if err := afero.WriteFile(m.fs, path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", BlockerFileName, err)
+	}
+	return nil
+}
+
+// writeResolved 更新 blocker 记录中的 "- Resolved:" 字段（缺失时插入到 Opened 之后）
+func (m *BlockerManager) writeResolved(projectPath string, blocker Blocker) error {
+	path := filepath.Join(projectPath, BlockerFileName)
+	data, err := afero.ReadFile(m.fs, path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", BlockerFileName, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	start := blocker.Line - 1
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "#") {
+			end = i
+			break
+		}
+	}
+
+	resolved := formatField("Resolved", blocker.Resolved)
+	insertAt := start + 1
+	written := false
+	for i := start + 1; i < end; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "- Resolved:") {
+			lines[i] = resolved
+			written = true
+			break
+		}
+		if strings.HasPrefix(trimmed, "- Opened:") || (insertAt == start+1 && strings.HasPrefix(trimmed, "- ")) {
+			insertAt = i + 1
+		}
+	}
+	if !written {
+		lines = insertLines(lines, insertAt, []string{resolved})
+	}
+
+	if err := afero.WriteFile(m.fs, path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", BlockerFileName, err)
+	}
+	return nil
+}
+
+// nextBlockerID 返回下一个可用的 B-<n> 编号
+func nextBlockerID(blockers []Blocker) string {
+	max := 0
+	for _, blocker := range blockers {
+		if matches := blockerNumberRegex.FindStringSubmatch(blocker.ID); matches != nil {
+			if n, err := strconv.Atoi(matches[1]); err == nil && n > max {
+				max = n
+			}
+		}
+	}
+	return fmt.Sprintf("B-%d", max+1)
+}
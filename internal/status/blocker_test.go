@@ -0,0 +1,117 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleBlockers = `# Blockers
+
+## B-<id>: <one-line title>
+- Feature: ` + "`<feature-key>`" + `
+
+## B-1: Legal review of discount terms
+- Feature: ` + "`checkout`" + `
+- Owner: alice
+- Opened: 2024-01-01
+- Resolved:
+- Description: waiting on legal sign-off
+
+## B-2: Missing SSO metadata
+- Feature: auth
+- Owner: bob
+- Opened: 2024-01-10
+- Resolved: 2024-01-15
+- Description: IdP metadata not shared yet
+
+## B-3: Vendor contract
+- Feature: billing
+- Opened: 2024-01-20
+`
+
+func TestParseBlockers(t *testing.T) {
+	blockers := ParseBlockers(sampleBlockers)
+	require.Len(t, blockers, 3)
+
+	assert.Equal(t, Blocker{
+		ID:          "B-1",
+		Title:       "Legal review of discount terms",
+		Feature:     "checkout",
+		Owner:       "alice",
+		Opened:      "2024-01-01",
+		Description: "waiting on legal sign-off",
+		Line:        6,
+	}, blockers[0])
+	assert.True(t, blockers[0].IsOpen())
+	assert.False(t, blockers[1].IsOpen())
+
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 31, blockers[0].AgeDays(now))
+	assert.Equal(t, 5, blockers[1].AgeDays(now))
+}
+
+func TestCrossCheckBlockers(t *testing.T) {
+	features := []Feature{
+		{Name: "checkout", Status: StatusBlocked},
+		{Name: "auth", Status: StatusBlocked},
+		{Name: "search", Status: StatusDesigned},
+	}
+	blockers := append(ParseBlockers(sampleBlockers), Blocker{ID: "B-4", Feature: "search", Opened: "2024-01-02"})
+
+	check := CrossCheckBlockers(features, blockers)
+	assert.True(t, check.HasIssues())
+	assert.Equal(t, []string{"auth"}, check.BlockedWithoutBlocker)
+	require.Len(t, check.OpenOnUnblocked, 1)
+	assert.Equal(t, "B-4", check.OpenOnUnblocked[0].ID)
+	require.Len(t, check.UnknownFeature, 1)
+	assert.Equal(t, "B-3", check.UnknownFeature[0].ID)
+}
+
+func TestBlockerManager_AddAndResolve(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/p/features/checkout.md", []byte(testFeature), 0644))
+
+	manager := NewBlockerManager(fs)
+	day := time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	first, err := manager.Add("/p", NewBlocker{Feature: "checkout", Title: "Legal review", Owner: "alice", Author: "bob", Date: day})
+	require.NoError(t, err)
+	assert.Equal(t, "B-1", first.Blocker.ID)
+	require.NotNil(t, first.StatusChange)
+	assert.Equal(t, StatusUnderDesign, first.StatusChange.From)
+
+	// A second blocker on an already blocked feature only adds a Changelog line
+	second, err := manager.Add("/p", NewBlocker{Feature: "checkout", Title: "Vendor contract", Author: "bob", Date: day})
+	require.NoError(t, err)
+	assert.Equal(t, "B-2", second.Blocker.ID)
+	assert.Nil(t, second.StatusChange)
+	assert.Equal(t, "2024-02-03 (bob): Blocker opened: B-2 Vendor contract", second.ChangelogEntry)
+
+	content, err := afero.ReadFile(fs, "/p/blocker.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Blockers\n\n"+
+		"## B-1: Legal review\n- Feature: `checkout`\n- Owner: alice\n- Opened: 2024-02-03\n- Resolved:\n- Description:\n\n"+
+		"## B-2: Vendor contract\n- Feature: `checkout`\n- Owner:\n- Opened: 2024-02-03\n- Resolved:\n- Description:\n", string(content))
+
+	resolved, err := manager.Resolve("/p", "b-1", "bob", day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	assert.Nil(t, resolved.StatusChange)
+	assert.Equal(t, []string{"B-2"}, resolved.StillBlockedBy)
+
+	resolved, err = manager.Resolve("/p", "B-2", "bob", day.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	require.NotNil(t, resolved.StatusChange)
+	assert.Equal(t, StatusUnderDesign, resolved.StatusChange.To)
+
+	blockers, err := NewBlockerParser(fs).ParseProject("/p")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-02-05", blockers[0].Resolved)
+	assert.Empty(t, OpenBlockers(blockers))
+
+	_, err = manager.Resolve("/p", "B-2", "bob", day)
+	assert.Error(t, err)
+}
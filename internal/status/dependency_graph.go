@@ -4,17 +4,20 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	depgraph "github.com/GarrickZ2/archie/internal/graph"
 )
 
 // DependencyGraph 表示 feature 之间的依赖关系图
 type DependencyGraph struct {
-	Features         []Feature
-	FeaturesByKey    map[string]*Feature
-	DependsOn        map[string][]string // feature-key -> list of dependencies
-	DependedBy       map[string][]string // feature-key -> list of dependents (reverse)
-	NoDependencies   []string            // features with no dependencies
-	HasDependencies  []string            // features with dependencies
-	CircularDeps     [][]string          // circular dependency chains (if any)
+	Features        []Feature
+	FeaturesByKey   map[string]*Feature
+	DependsOn       map[string][]string // feature-key -> list of dependencies
+	DependedBy      map[string][]string // feature-key -> list of dependents (reverse)
+	NoDependencies  []string            // features with no dependencies
+	HasDependencies []string            // features with dependencies
+	CircularDeps    [][]string          // 所有基本环（最多 graph.MaxCycles 个）
+	Components      [][]string          // 包含环的强连通分量
 }
 
 // BuildDependencyGraph 从 features 构建依赖图
@@ -61,64 +64,63 @@ func BuildDependencyGraph(features []Feature) *DependencyGraph {
 		sort.Strings(graph.DependedBy[key])
 	}
 
-	// 检测循环依赖 (简单实现，可以优化)
-	graph.CircularDeps = detectCircularDependencies(graph)
+	// 通过强连通分量检测所有循环依赖
+	graph.Components = depgraph.CyclicComponents(graph.DependsOn)
+	graph.CircularDeps = depgraph.Cycles(graph.DependsOn, graph.Components)
 
 	return graph
 }
 
-// detectCircularDependencies 检测循环依赖
-func detectCircularDependencies(graph *DependencyGraph) [][]string {
-	// 简单的 DFS 检测
-	visited := make(map[string]bool)
-	recStack := make(map[string]bool)
-	cycles := [][]string{}
-
-	var dfs func(node string, path []string) bool
-	dfs = func(node string, path []string) bool {
-		visited[node] = true
-		recStack[node] = true
-		path = append(path, node)
-
-		for _, dep := range graph.DependsOn[node] {
-			if !visited[dep] {
-				if dfs(dep, path) {
-					return true
-				}
-			} else if recStack[dep] {
-				// 发现循环
-				cycleStart := 0
-				for i, n := range path {
-					if n == dep {
-						cycleStart = i
-						break
-					}
-				}
-				cycle := append([]string{}, path[cycleStart:]...)
-				cycles = append(cycles, cycle)
-				return true
-			}
+// dependsOnItself 检查 feature 是否依赖自身
+func (g *DependencyGraph) dependsOnItself(key string) bool {
+	for _, dep := range g.DependsOn[key] {
+		if dep == key {
+			return true
 		}
-
-		recStack[node] = false
-		return false
 	}
+	return false
+}
 
-	for _, feature := range graph.Features {
-		if !visited[feature.Name] {
-			dfs(feature.Name, []string{})
+// InCycle 检查依赖边 from → to（from 依赖 to）是否位于某个环上
+func (g *DependencyGraph) InCycle(from, to string) bool {
+	for _, component := range g.Components {
+		hasFrom, hasTo := false, false
+		for _, key := range component {
+			hasFrom = hasFrom || key == from
+			hasTo = hasTo || key == to
+		}
+		if hasFrom && hasTo {
+			if from == to {
+				return g.dependsOnItself(from)
+			}
+			return true
 		}
 	}
-
-	return cycles
+	return false
 }
 
 // GetTopologicalOrder 获取拓扑排序（设计顺序建议）
+// 位于环上或依赖环的 features 不在结果中，见 GetUnorderedFeatures
 func (g *DependencyGraph) GetTopologicalOrder() []string {
-	// Kahn 算法
+	order, _ := g.topologicalSort()
+	return order
+}
+
+// GetUnorderedFeatures 返回无法进入拓扑排序的 features（位于环上或依赖环）
+func (g *DependencyGraph) GetUnorderedFeatures() []string {
+	_, unordered := g.topologicalSort()
+	return unordered
+}
+
+// topologicalSort Kahn 算法；指向不存在 feature 的依赖不参与排序
+func (g *DependencyGraph) topologicalSort() ([]string, []string) {
 	inDegree := make(map[string]int)
 	for _, feature := range g.Features {
-		inDegree[feature.Name] = len(g.DependsOn[feature.Name])
+		for _, dep := range g.DependsOn[feature.Name] {
+			if _, exists := g.FeaturesByKey[dep]; exists {
+				inDegree[feature.Name]++
+			}
+		}
 	}
 
 	queue := []string{}
@@ -129,6 +131,7 @@ func (g *DependencyGraph) GetTopologicalOrder() []string {
 	}
 
 	result := []string{}
+	ordered := make(map[string]bool)
 	for len(queue) > 0 {
 		// 按字母顺序排序以保证确定性输出
 		sort.Strings(queue)
@@ -136,6 +139,7 @@ func (g *DependencyGraph) GetTopologicalOrder() []string {
 		current := queue[0]
 		queue = queue[1:]
 		result = append(result, current)
+		ordered[current] = true
 
 		for _, dependent := range g.DependedBy[current] {
 			inDegree[dependent]--
@@ -145,7 +149,27 @@ func (g *DependencyGraph) GetTopologicalOrder() []string {
 		}
 	}
 
-	return result
+	unordered := []string{}
+	for _, feature := range g.Features {
+		if !ordered[feature.Name] {
+			unordered = append(unordered, feature.Name)
+		}
+	}
+	sort.Strings(unordered)
+
+	return result, unordered
+}
+
+// IsInCycle 检查 feature 是否位于某个环上
+func (g *DependencyGraph) IsInCycle(key string) bool {
+	for _, component := range g.Components {
+		for _, member := range component {
+			if member == key {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // DependencyGraphDisplay 负责展示依赖图
@@ -178,20 +202,62 @@ func (d *DependencyGraphDisplay) Show() {
 	d.showDependencyTree()
 	fmt.Println()
 
+	// 显示依赖未就绪的 features
+	if d.showReadiness() {
+		fmt.Println()
+	}
+
 	// 显示推荐的设计顺序
 	d.showDesignOrder()
 }
 
+// showReadiness 显示依赖尚未就绪、无法进入下一阶段的 features
+// 没有需要展示的内容时返回 false
+func (d *DependencyGraphDisplay) showReadiness() bool {
+	notReady := []*DependencyReadiness{}
+	for _, readiness := range d.graph.AllReadiness() {
+		if !readiness.Ready {
+			notReady = append(notReady, readiness)
+		}
+	}
+
+	if len(notReady) == 0 {
+		return false
+	}
+
+	fmt.Println(ColorYellow + ColorBold + "  🚦 Dependency Readiness" + ColorReset)
+	fmt.Println()
+
+	for _, readiness := range notReady {
+		targetName := strings.ReplaceAll(string(readiness.Target), "_", " ")
+		fmt.Printf("  %s%-30s%s %snot ready for %s%s\n",
+			ColorBold, readiness.Feature, ColorReset,
+			ColorYellow, targetName, ColorReset)
+		fmt.Printf("     %swaiting on: %s%s\n", ColorDim, readiness.Describe(), ColorReset)
+	}
+
+	return true
+}
+
 // showCircularDependencies 显示循环依赖警告
 func (d *DependencyGraphDisplay) showCircularDependencies() {
 	fmt.Println(ColorRed + ColorBold + "  ⚠️  Circular Dependencies Detected!" + ColorReset)
 	fmt.Println()
 
+	for i, component := range d.graph.Components {
+		fmt.Printf("  %sCluster %d:%s %s\n", ColorRed, i+1, ColorReset, strings.Join(component, ", "))
+	}
+	fmt.Println()
+
 	for i, cycle := range d.graph.CircularDeps {
 		fmt.Printf("  %sCircle %d:%s ", ColorRed, i+1, ColorReset)
 		cycleStr := strings.Join(cycle, " → ")
 		fmt.Printf("%s → %s\n", cycleStr, cycle[0])
 	}
+
+	if len(d.graph.CircularDeps) >= depgraph.MaxCycles {
+		fmt.Printf("  %s(only the first %d cycles are shown)%s\n", ColorDim, depgraph.MaxCycles, ColorReset)
+	}
 }
 
 // showDependencyTree 显示树状依赖图
@@ -351,9 +417,9 @@ func (d *DependencyGraphDisplay) showFeatureNode(featureKey string, prefix strin
 
 // showDesignOrder 显示推荐的设计顺序
 func (d *DependencyGraphDisplay) showDesignOrder() {
-	order := d.graph.GetTopologicalOrder()
+	order, unordered := d.graph.topologicalSort()
 
-	if len(order) == 0 {
+	if len(order) == 0 && len(unordered) == 0 {
 		return
 	}
 
@@ -377,8 +443,23 @@ func (d *DependencyGraphDisplay) showDesignOrder() {
 			feature.Name,
 			statusColor, statusName, ColorReset)
 	}
-}
 
+	if len(unordered) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ColorRed + ColorBold + "  ⚠️  Not Ordered (circular dependencies)" + ColorReset)
+	fmt.Println()
+
+	for _, featureKey := range unordered {
+		reason := "depends on a cycle"
+		if d.graph.IsInCycle(featureKey) {
+			reason = "in a cycle"
+		}
+		fmt.Printf("     %-30s %s(%s)%s\n", featureKey, ColorDim, reason, ColorReset)
+	}
+}
 
 // ShowCompact 显示紧凑版本的依赖图
 func (d *DependencyGraphDisplay) ShowCompact() {
@@ -395,5 +476,15 @@ func (d *DependencyGraphDisplay) ShowCompact() {
 		fmt.Printf(" | %s%d circular%s", ColorRed, len(d.graph.CircularDeps), ColorReset)
 	}
 
+	notReady := 0
+	for _, readiness := range d.graph.AllReadiness() {
+		if !readiness.Ready {
+			notReady++
+		}
+	}
+	if notReady > 0 {
+		fmt.Printf(" | %s%d waiting on dependencies%s", ColorYellow, notReady, ColorReset)
+	}
+
 	fmt.Println()
 }
@@ -0,0 +1,119 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func feature(name string, deps ...string) Feature {
+	f := Feature{Name: name, Status: StatusNotReviewed, Dependencies: make(map[string]string)}
+	for _, dep := range deps {
+		f.Dependencies[dep] = ""
+	}
+	return f
+}
+
+func TestBuildDependencyGraph_ReportsEveryCycle(t *testing.T) {
+	graph := BuildDependencyGraph([]Feature{
+		// a ↔ b and b → c → a share one component with two cycles
+		feature("a", "b"),
+		feature("b", "a", "c"),
+		feature("c", "a"),
+		// a separate two-node cycle
+		feature("x", "y"),
+		feature("y", "x"),
+		// self dependency
+		feature("self", "self"),
+		// downstream of a cycle, not part of one
+		feature("downstream", "x"),
+		feature("root"),
+		feature("leaf", "root", "missing"),
+	})
+
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"self"}, {"x", "y"}}, graph.Components)
+	assert.Equal(t, [][]string{{"a", "b"}, {"a", "b", "c"}, {"self"}, {"x", "y"}}, graph.CircularDeps)
+
+	assert.True(t, graph.InCycle("c", "a"))
+	assert.True(t, graph.InCycle("self", "self"))
+	assert.False(t, graph.InCycle("downstream", "x"))
+
+	order, unordered := graph.topologicalSort()
+	assert.Equal(t, []string{"root", "leaf"}, order)
+	assert.Equal(t, []string{"a", "b", "c", "downstream", "self", "x", "y"}, unordered)
+	assert.True(t, graph.IsInCycle("x"))
+	assert.False(t, graph.IsInCycle("downstream"))
+}
+
+func TestBuildDependencyGraph_NoCycles(t *testing.T) {
+	graph := BuildDependencyGraph([]Feature{
+		feature("api", "db"),
+		feature("db"),
+		feature("ui", "api"),
+	})
+
+	assert.Empty(t, graph.Components)
+	assert.Empty(t, graph.CircularDeps)
+	assert.Equal(t, []string{"db", "api", "ui"}, graph.GetTopologicalOrder())
+	assert.Empty(t, graph.GetUnorderedFeatures())
+}
+
+func TestDependencyGraph_Impact(t *testing.T) {
+	api := feature("api", "auth")
+	api.Status = StatusSpecReady
+	ui := feature("ui", "api", "auth")
+	ui.Status = StatusUnderDesign
+	mobile := feature("mobile", "ui")
+	mobile.Status = StatusImplementing
+
+	graph := BuildDependencyGraph([]Feature{feature("auth"), api, ui, mobile, feature("billing")})
+
+	report, err := graph.Impact("auth")
+	assert.NoError(t, err)
+	assert.Len(t, report.Impacted, 3)
+
+	assert.Equal(t, "api", report.Impacted[0].Key)
+	assert.Equal(t, 1, report.Impacted[0].Depth)
+	assert.True(t, report.Impacted[0].NeedsReview)
+
+	// ui depends on auth directly, so the shortest chain wins
+	assert.Equal(t, "ui", report.Impacted[1].Key)
+	assert.Equal(t, 1, report.Impacted[1].Depth)
+	assert.False(t, report.Impacted[1].NeedsReview)
+
+	assert.Equal(t, "mobile", report.Impacted[2].Key)
+	assert.Equal(t, []string{"auth", "ui", "mobile"}, report.Impacted[2].Path)
+	assert.Equal(t, []string{"api", "mobile"}, report.NeedsReview)
+
+	report, err = graph.Impact("billing")
+	assert.NoError(t, err)
+	assert.Empty(t, report.Impacted)
+
+	_, err = graph.Impact("missing")
+	assert.Error(t, err)
+}
+
+func TestDependencyGraph_Readiness(t *testing.T) {
+	auth := feature("auth")
+	auth.Status = StatusDesigned
+	billing := feature("billing")
+	billing.Status = StatusBlocked
+	checkout := feature("checkout", "auth", "billing", "ghost")
+	checkout.Status = StatusReadyForDesign
+	profile := feature("profile", "auth")
+	profile.Status = StatusDesigned
+
+	graph := BuildDependencyGraph([]Feature{auth, billing, checkout, profile})
+
+	readiness := graph.Readiness("checkout")
+	assert.Equal(t, StatusUnderDesign, readiness.Target)
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, []UnmetDependency{
+		{Key: "billing", Status: StatusBlocked},
+		{Key: "ghost", Missing: true},
+	}, readiness.Unmet)
+	assert.Equal(t, "billing (BLOCKED), ghost (missing)", readiness.Describe())
+
+	assert.True(t, graph.Readiness("profile").Ready)
+	assert.True(t, graph.ReadinessFor("checkout", StatusUnderReview).Ready)
+}
@@ -5,60 +5,63 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/afero"
+
+	"github.com/GarrickZ2/archie/internal/tasks"
 )
 
 // FeatureDetail 包含 feature 的完整详细信息
 type FeatureDetail struct {
 	// 基本信息
-	Key      string
-	FilePath string
+	Key      string `json:"key" yaml:"key"`
+	FilePath string `json:"file_path" yaml:"file_path"`
 
 	// Status section
-	Status      FeatureStatus
-	Owner       string
-	LastUpdated string
-	Reason      string
+	Status      FeatureStatus `json:"status" yaml:"status"`
+	Owner       string        `json:"owner" yaml:"owner"`
+	LastUpdated string        `json:"last_updated" yaml:"last_updated"`
+	Reason      string        `json:"reason" yaml:"reason"`
 
 	// Summary section
-	OneLiner   string
-	Background string
-	UserStory  string
+	OneLiner   string `json:"one_liner" yaml:"one_liner"`
+	Background string `json:"background" yaml:"background"`
+	UserStory  string `json:"user_story" yaml:"user_story"`
 
 	// Scope section
-	InScope  []string
-	OutScope []string
+	InScope  []string `json:"in_scope" yaml:"in_scope"`
+	OutScope []string `json:"out_scope" yaml:"out_scope"`
 
 	// Requirements
-	Requirements    []string
-	NonRequirements []string
+	Requirements    []string `json:"requirements" yaml:"requirements"`
+	NonRequirements []string `json:"non_requirements" yaml:"non_requirements"`
 
 	// Feature Dependencies (features that should be designed before this one)
-	FeatureDependencies map[string]string // feature-key -> reason
+	FeatureDependencies map[string]string `json:"feature_dependencies" yaml:"feature_dependencies"` // feature-key -> reason
 
 	// Acceptance Criteria
-	AcceptanceCriteria []string
+	AcceptanceCriteria []string `json:"acceptance_criteria" yaml:"acceptance_criteria"`
 
 	// Design Constraints
-	DesignConstraints []string
+	DesignConstraints []string `json:"design_constraints" yaml:"design_constraints"`
 
 	// Design Artifacts
-	APIDesign      string
-	StorageDesign  string
-	WorkflowDesign string
-	MetricsDesign  string
-	TasksDesign    string
+	APIDesign      string `json:"api_design" yaml:"api_design"`
+	StorageDesign  string `json:"storage_design" yaml:"storage_design"`
+	WorkflowDesign string `json:"workflow_design" yaml:"workflow_design"`
+	MetricsDesign  string `json:"metrics_design" yaml:"metrics_design"`
+	TasksDesign    string `json:"tasks_design" yaml:"tasks_design"`
 
 	// Spec
-	SpecLocation  string
-	SpecReadiness string
+	SpecLocation  string `json:"spec_location" yaml:"spec_location"`
+	SpecReadiness string `json:"spec_readiness" yaml:"spec_readiness"`
 
 	// Related Records
-	Blockers string
+	Blockers string `json:"blockers" yaml:"blockers"`
 
 	// Changelog
-	Changelog []string
+	Changelog []string `json:"changelog" yaml:"changelog"`
 }
 
 // DetailParser 解析 feature 详细信息
@@ -284,7 +287,10 @@ func (p *DetailParser) parseRelatedRecords(detail *FeatureDetail, line string) {
 
 // DetailDisplay 负责展示详细的 feature 信息
 type DetailDisplay struct {
-	detail *FeatureDetail
+	detail   *FeatureDetail
+	tasks    *tasks.FeatureTasks // tasks.md 中该 feature 的任务（可能为 nil）
+	blockers []Blocker           // blocker.md 中该 feature 的记录
+	history  *FeatureHistory     // 状态时间线（可能为 nil）
 }
 
 // NewDetailDisplay 创建详细信息展示器
@@ -292,6 +298,26 @@ func NewDetailDisplay(detail *FeatureDetail) *DetailDisplay {
 	return &DetailDisplay{detail: detail}
 }
 
+// SetTasks 设置该 feature 在 tasks.md 中的任务
+func (d *DetailDisplay) SetTasks(featureTasks *tasks.FeatureTasks) {
+	d.tasks = featureTasks
+}
+
+// SetBlockers 设置 blocker.md 中的记录（只保留该 feature 的）
+func (d *DetailDisplay) SetBlockers(blockers []Blocker) {
+	d.blockers = nil
+	for _, blocker := range blockers {
+		if blocker.Feature == d.detail.Key {
+			d.blockers = append(d.blockers, blocker)
+		}
+	}
+}
+
+// SetHistory 设置该 feature 的状态时间线
+func (d *DetailDisplay) SetHistory(history *FeatureHistory) {
+	d.history = history
+}
+
 // Show 展示详细的 feature 信息
 func (d *DetailDisplay) Show() {
 	fmt.Println()
@@ -341,11 +367,26 @@ func (d *DetailDisplay) Show() {
 		d.showSpec()
 	}
 
+	if d.tasks != nil && len(d.tasks.Tasks) > 0 {
+		fmt.Println()
+		d.showTasks()
+	}
+
 	if d.detail.Blockers != "" {
 		fmt.Println()
 		d.showRelatedRecords()
 	}
 
+	if len(d.blockers) > 0 {
+		fmt.Println()
+		d.showBlockers()
+	}
+
+	if d.history != nil && len(d.history.Events) > 0 {
+		fmt.Println()
+		d.showHistory()
+	}
+
 	if len(d.detail.Changelog) > 0 {
 		fmt.Println()
 		d.showChangelog()
@@ -391,12 +432,76 @@ func (d *DetailDisplay) showStatus() {
 		fmt.Printf("  %s%-15s%s %s\n", ColorDim, "Reason:", ColorReset, d.detail.Reason)
 	}
 
+	if d.history != nil {
+		if days := d.history.DaysInCurrentStatus(); days >= 0 && d.detail.Status != StatusFinished {
+			fmt.Printf("  %s%-15s%s %dd\n", ColorDim, "In Status:", ColorReset, days)
+		}
+		if d.history.CycleDays >= 0 {
+			fmt.Printf("  %s%-15s%s %dd\n", ColorDim, "Cycle Time:", ColorReset, d.history.CycleDays)
+		}
+	}
+
 	// 显示进度条
 	progress := GetStatusProgress(d.detail.Status)
 	if progress > 0 {
 		fmt.Println()
 		d.showProgressBar(progress)
 	}
+
+	if d.tasks != nil && len(d.tasks.Tasks) > 0 {
+		taskProgress := d.tasks.Progress()
+		fmt.Printf("  %sTasks:%s    %d/%d done, %d in progress (%d%%)\n",
+			ColorDim, ColorReset,
+			taskProgress.Done, taskProgress.Total, taskProgress.Doing, taskProgress.Percent())
+	}
+}
+
+// showTasks 显示 tasks.md 中该 feature 的任务
+func (d *DetailDisplay) showTasks() {
+	fmt.Println(ColorBold + "  ✅ Tasks" + ColorReset)
+	fmt.Println()
+
+	for _, task := range d.tasks.Tasks {
+		checkbox, color := "[ ]", ColorDim
+		switch task.Status {
+		case tasks.StatusDoing:
+			checkbox, color = "[>]", ColorBlue
+		case tasks.StatusDone:
+			checkbox, color = "[x]", ColorGreen
+		case tasks.StatusUnknown:
+			checkbox, color = "[?]", ColorRed
+		}
+
+		fmt.Printf("  %s%s%s %-8s %s", color, checkbox, ColorReset, task.ID, task.Title)
+		if task.Owner != "" {
+			fmt.Printf(" %s(%s)%s", ColorDim, task.Owner, ColorReset)
+		}
+		if task.ETA != "" {
+			fmt.Printf(" %sETA %s%s", ColorDim, task.ETA, ColorReset)
+		}
+		fmt.Println()
+	}
+}
+
+// showHistory 显示状态时间线（每个状态的停留时间）
+func (d *DetailDisplay) showHistory() {
+	fmt.Printf("%s  🕒 Status Timeline%s %s(from %s)%s\n", ColorBold, ColorReset, ColorDim, d.history.Source, ColorReset)
+	fmt.Println()
+
+	for _, span := range d.history.Spans {
+		statusName := strings.ReplaceAll(string(span.Status), "_", " ")
+		end := span.End.Format("2006-01-02")
+		if span.Open {
+			end = "now"
+			if span.Status == StatusFinished {
+				end = "done"
+			}
+		}
+		fmt.Printf("  %s%-18s%s %s → %-10s %s%4dd%s\n",
+			GetStatusColor(span.Status), statusName, ColorReset,
+			span.Start.Format("2006-01-02"), end,
+			ColorDim, span.Days, ColorReset)
+	}
 }
 
 // showProgressBar 显示进度条
@@ -593,6 +698,24 @@ func (d *DetailDisplay) showRelatedRecords() {
 	}
 }
 
+// showBlockers 显示 blocker.md 中该 feature 的 blockers
+func (d *DetailDisplay) showBlockers() {
+	fmt.Println(ColorBold + "  🧱 Blockers" + ColorReset)
+	fmt.Println()
+
+	now := time.Now()
+	for _, blocker := range d.blockers {
+		state := fmt.Sprintf("%sopen %dd%s", ColorRed, blocker.AgeDays(now), ColorReset)
+		if !blocker.IsOpen() {
+			state = fmt.Sprintf("%sresolved %s%s", ColorGreen, blocker.Resolved, ColorReset)
+		}
+		fmt.Printf("  %s%-6s%s %s %s\n", ColorBold, blocker.ID, ColorReset, blocker.Title, state)
+		if blocker.Description != "" {
+			fmt.Printf("         %s%s%s\n", ColorDim, blocker.Description, ColorReset)
+		}
+	}
+}
+
 // showChangelog 显示变更日志
 func (d *DetailDisplay) showChangelog() {
 	fmt.Println(ColorBold + "  📅 Changelog" + ColorReset)
@@ -3,6 +3,9 @@ package status
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/GarrickZ2/archie/internal/tasks"
 )
 
 const (
@@ -19,8 +22,12 @@ const (
 
 // Display 负责展示状态信息
 type Display struct {
-	summary  *Summary
-	features []Feature
+	summary      *Summary
+	features     []Feature
+	taskProgress map[string]tasks.Progress // feature-key -> tasks.md 完成情况
+	taskIssues   []tasks.Issue
+	blockers     []Blocker // blocker.md 中的记录
+	flow         *FlowMetrics
 }
 
 // NewDisplay 创建展示器
@@ -39,6 +46,22 @@ func NewDisplayWithFeatures(summary *Summary, features []Feature) *Display {
 	}
 }
 
+// SetTasks 设置 tasks.md 的解析结果，用于展示每个 feature 的任务完成情况
+func (d *Display) SetTasks(list *tasks.TaskList) {
+	d.taskProgress = list.ProgressByFeature()
+	d.taskIssues = list.Validate()
+}
+
+// SetBlockers 设置 blocker.md 的解析结果，用于 blocker 老化和一致性检查
+func (d *Display) SetBlockers(blockers []Blocker) {
+	d.blockers = blockers
+}
+
+// SetFlow 设置基于状态历史的流转统计
+func (d *Display) SetFlow(flow *FlowMetrics) {
+	d.flow = flow
+}
+
 // Show 展示完整的状态报告
 func (d *Display) Show() {
 	fmt.Println()
@@ -62,6 +85,25 @@ func (d *Display) Show() {
 		d.showStaleFeatures()
 	}
 
+	if d.hasWIPViolations() {
+		fmt.Println()
+		d.showWIPViolations()
+	}
+
+	if len(d.blockers) > 0 || len(d.summary.BlockedFeatures) > 0 {
+		d.showBlockerAging()
+	}
+
+	if d.flow != nil && d.flow.HasData() {
+		fmt.Println()
+		d.flow.Show()
+	}
+
+	if len(d.taskIssues) > 0 {
+		fmt.Println()
+		d.showTaskIssues()
+	}
+
 	// 显示依赖图（如果有 features 数据）
 	if len(d.features) > 0 {
 		fmt.Println()
@@ -252,20 +294,127 @@ func (d *Display) showBlockedFeatures() {
 	}
 }
 
-// showStaleFeatures 显示过期的 features
+// blockerAgeBuckets blocker 老化分段（天）
+var blockerAgeBuckets = []struct {
+	label string
+	max   int
+}{
+	{"< 1 week", 6},
+	{"1-2 weeks", 13},
+	{"2-4 weeks", 29},
+	{"30+ days", -1},
+}
+
+// showBlockerAging 显示未解决 blockers 的老化情况，以及与 BLOCKED 状态不一致的记录
+func (d *Display) showBlockerAging() {
+	open := OpenBlockers(d.blockers)
+	check := CrossCheckBlockers(d.features, d.blockers)
+
+	if len(open) == 0 && !check.HasIssues() {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ColorBold + ColorRed + "  🧱 Blocker Aging" + ColorReset)
+	fmt.Println()
+
+	now := time.Now()
+	if len(open) > 0 {
+		counts := make([]int, len(blockerAgeBuckets))
+		for _, blocker := range open {
+			age := blocker.AgeDays(now)
+			for i, bucket := range blockerAgeBuckets {
+				if bucket.max < 0 || age <= bucket.max {
+					counts[i]++
+					break
+				}
+			}
+		}
+
+		for i, bucket := range blockerAgeBuckets {
+			fmt.Printf("  %s%-12s%s %d\n", ColorDim, bucket.label+":", ColorReset, counts[i])
+		}
+		fmt.Println()
+
+		for _, blocker := range open {
+			age := blocker.AgeDays(now)
+			ageText := "unknown age"
+			ageColor := ColorDim
+			if age >= 0 {
+				ageText = fmt.Sprintf("%dd", age)
+				ageColor = ColorYellow
+				if age >= 30 {
+					ageColor = ColorRed
+				}
+			}
+
+			owner := blocker.Owner
+			if owner == "" {
+				owner = "Unassigned"
+			}
+
+			fmt.Printf("  %s%-6s%s %-24s %s%-8s%s %s[%s]%s %s\n",
+				ColorBold, blocker.ID, ColorReset,
+				blocker.Feature,
+				ageColor, ageText, ColorReset,
+				ColorDim, owner, ColorReset,
+				blocker.Title)
+		}
+	}
+
+	if check.HasIssues() {
+		fmt.Println()
+		for _, featureKey := range check.BlockedWithoutBlocker {
+			fmt.Printf("  %s•%s %s is BLOCKED but has no open blocker in %s\n", ColorYellow, ColorReset, featureKey, BlockerFileName)
+		}
+		for _, blocker := range check.OpenOnUnblocked {
+			fmt.Printf("  %s•%s %s is open but %s is not BLOCKED\n", ColorYellow, ColorReset, blocker.ID, blocker.Feature)
+		}
+		for _, blocker := range check.UnknownFeature {
+			fmt.Printf("  %s•%s %s refers to unknown feature '%s'\n", ColorYellow, ColorReset, blocker.ID, blocker.Feature)
+		}
+	}
+}
+
+// showStaleFeatures 显示在当前状态停留超过阈值的 features
 func (d *Display) showStaleFeatures() {
-	fmt.Println(ColorBold + ColorYellow + "  ⏰ Stale Features (Not Updated in 30+ Days)" + ColorReset)
+	fmt.Println(ColorBold + ColorYellow + "  ⏰ Stale Features (Past Their Status Threshold)" + ColorReset)
 	fmt.Println()
 
-	for _, feature := range d.summary.StaleFeatures {
-		statusName := strings.ReplaceAll(string(feature.Status), "_", " ")
-		fmt.Printf("  %s%-30s%s %s%-18s%s %sLast: %s%s\n",
-			ColorBold, feature.Name, ColorReset,
+	for _, violation := range d.summary.Violations {
+		if violation.Kind != ViolationStale {
+			continue
+		}
+		statusName := strings.ReplaceAll(string(violation.Status), "_", " ")
+		fmt.Printf("  %s%-30s%s %s%-18s%s %s%dd (limit %dd)%s\n",
+			ColorBold, violation.Subject, ColorReset,
 			ColorDim, statusName, ColorReset,
-			ColorDim, feature.LastUpdated, ColorReset)
+			ColorYellow, violation.Actual, violation.Limit, ColorReset)
 	}
 }
 
+// showWIPViolations 显示超过 WIP 上限的状态和 owners
+func (d *Display) showWIPViolations() {
+	fmt.Println(ColorBold + ColorRed + "  🚦 WIP Limits Exceeded" + ColorReset)
+	fmt.Println()
+
+	for _, violation := range d.summary.Violations {
+		if violation.Kind != ViolationStale {
+			fmt.Printf("  %s•%s %s\n", ColorRed, ColorReset, violation.String())
+		}
+	}
+}
+
+// hasWIPViolations 是否有 WIP 超限
+func (d *Display) hasWIPViolations() bool {
+	for _, violation := range d.summary.Violations {
+		if violation.Kind != ViolationStale {
+			return true
+		}
+	}
+	return false
+}
+
 // showDetailedFeatureList 显示详细的 feature 列表（按状态分组）
 func (d *Display) showDetailedFeatureList() {
 	fmt.Println(ColorBold + "  📋 Features by Status" + ColorReset)
@@ -293,15 +442,45 @@ func (d *Display) showDetailedFeatureList() {
 				updated = ColorDim + "not set" + ColorReset
 			}
 
-			fmt.Printf("    • %-30s %s[%s]%s  %sUpdated: %s%s\n",
+			fmt.Printf("    • %-30s %s[%s]%s  %sUpdated: %s%s%s\n",
 				feature.Name,
 				ColorDim, owner, ColorReset,
-				ColorDim, updated, ColorReset)
+				ColorDim, updated, ColorReset,
+				d.formatTaskProgress(feature.Name))
 		}
 		fmt.Println()
 	}
 }
 
+// formatTaskProgress 格式化 feature 的任务完成情况（没有任务时返回空字符串）
+func (d *Display) formatTaskProgress(featureKey string) string {
+	progress, ok := d.taskProgress[featureKey]
+	if !ok || progress.Total == 0 {
+		return ""
+	}
+
+	color := ColorYellow
+	if progress.Done == progress.Total {
+		color = ColorGreen
+	}
+
+	text := fmt.Sprintf("  %sTasks: %d/%d done%s", color, progress.Done, progress.Total, ColorReset)
+	if progress.Doing > 0 {
+		text += fmt.Sprintf(" %s(%d in progress)%s", ColorDim, progress.Doing, ColorReset)
+	}
+	return text
+}
+
+// showTaskIssues 显示 tasks.md 中的问题（缺失依赖、循环依赖等）
+func (d *Display) showTaskIssues() {
+	fmt.Println(ColorBold + ColorYellow + "  ⚠️  Task Plan Issues" + ColorReset)
+	fmt.Println()
+
+	for _, issue := range d.taskIssues {
+		fmt.Printf("  %s•%s %s\n", ColorYellow, ColorReset, issue.String())
+	}
+}
+
 // getPercentage 计算百分比
 func (d *Display) getPercentage(count int) float64 {
 	if d.summary.TotalFeatures == 0 {
@@ -343,5 +522,27 @@ func (d *Display) ShowCompact() {
 
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 	fmt.Printf("%s%s%s %d%%\n", barColor, bar, ColorReset, progress)
+
+	if d.flow != nil {
+		if line := d.flow.CompactLine(); line != "" {
+			fmt.Printf("%s⏳ %s%s\n", ColorDim, line, ColorReset)
+		}
+	}
+
+	// 过期和 WIP 超限，站会上需要处理的问题
+	for _, violation := range d.summary.Violations {
+		icon, color := "🚦", ColorRed
+		if violation.Kind == ViolationStale {
+			icon, color = "⏰", ColorYellow
+		}
+		fmt.Printf("%s%s %s%s\n", color, icon, violation.String(), ColorReset)
+	}
+
+	// 进行中的 features 显示任务完成情况，区分同一状态下的实际进展
+	for _, feature := range d.summary.FeaturesByStatus[StatusImplementing] {
+		if taskText := d.formatTaskProgress(feature.Name); taskText != "" {
+			fmt.Printf("  %-30s%s\n", feature.Name, taskText)
+		}
+	}
 	fmt.Println()
 }
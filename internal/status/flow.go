@@ -0,0 +1,157 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxBottlenecks 报告中列出的瓶颈阶段数量
+const maxBottlenecks = 3
+
+// StageStats 某个状态的停留时间统计
+type StageStats struct {
+	Status   FeatureStatus `json:"status" yaml:"status"`
+	Features int           `json:"features" yaml:"features"` // 经过该状态的 feature 数
+	AvgDays  float64       `json:"avg_days" yaml:"avg_days"`
+	MaxDays  int           `json:"max_days" yaml:"max_days"`
+	Current  []string      `json:"current" yaml:"current"` // 当前处于该状态的 features
+}
+
+// FlowMetrics 基于状态历史的流转统计
+type FlowMetrics struct {
+	Stages          []StageStats      `json:"stages" yaml:"stages"`           // 按 AllStatuses 顺序
+	Bottlenecks     []StageStats      `json:"bottlenecks" yaml:"bottlenecks"` // 平均停留最久的阶段
+	CycleTimes      map[string]int    `json:"cycle_times" yaml:"cycle_times"` // feature-key -> 天数（仅已完成）
+	AvgCycleDays    float64           `json:"avg_cycle_days" yaml:"avg_cycle_days"`
+	MedianCycleDays float64           `json:"median_cycle_days" yaml:"median_cycle_days"`
+	Histories       []*FeatureHistory `json:"histories" yaml:"histories"`
+}
+
+// HasData 是否有可用的时间数据
+func (m *FlowMetrics) HasData() bool {
+	return len(m.Stages) > 0
+}
+
+// AnalyzeFlow 汇总每个状态的停留时间、周期时间和瓶颈阶段
+func AnalyzeFlow(histories []*FeatureHistory) *FlowMetrics {
+	metrics := &FlowMetrics{
+		Stages:      []StageStats{},
+		Bottlenecks: []StageStats{},
+		CycleTimes:  make(map[string]int),
+		Histories:   histories,
+	}
+
+	totals := make(map[FeatureStatus]int)
+	visits := make(map[FeatureStatus]int)
+	maxDays := make(map[FeatureStatus]int)
+	current := make(map[FeatureStatus][]string)
+
+	for _, history := range histories {
+		for status, days := range history.TimeInState() {
+			totals[status] += days
+			visits[status]++
+			if days > maxDays[status] {
+				maxDays[status] = days
+			}
+		}
+		if span := history.CurrentSpan(); span != nil {
+			current[span.Status] = append(current[span.Status], history.Feature)
+		}
+		if history.CycleDays >= 0 {
+			metrics.CycleTimes[history.Feature] = history.CycleDays
+		}
+	}
+
+	for _, status := range AllStatuses {
+		if visits[status] == 0 {
+			continue
+		}
+		metrics.Stages = append(metrics.Stages, StageStats{
+			Status:   status,
+			Features: visits[status],
+			AvgDays:  float64(totals[status]) / float64(visits[status]),
+			MaxDays:  maxDays[status],
+			Current:  nonNilStrings(current[status]),
+		})
+	}
+
+	// FINISHED 是终态，不算瓶颈
+	for _, stage := range metrics.Stages {
+		if stage.Status != StatusFinished && stage.AvgDays > 0 {
+			metrics.Bottlenecks = append(metrics.Bottlenecks, stage)
+		}
+	}
+	sort.SliceStable(metrics.Bottlenecks, func(i, j int) bool {
+		return metrics.Bottlenecks[i].AvgDays > metrics.Bottlenecks[j].AvgDays
+	})
+	if len(metrics.Bottlenecks) > maxBottlenecks {
+		metrics.Bottlenecks = metrics.Bottlenecks[:maxBottlenecks]
+	}
+
+	if len(metrics.CycleTimes) > 0 {
+		cycles := make([]int, 0, len(metrics.CycleTimes))
+		sum := 0
+		for _, days := range metrics.CycleTimes {
+			cycles = append(cycles, days)
+			sum += days
+		}
+		sort.Ints(cycles)
+		metrics.AvgCycleDays = float64(sum) / float64(len(cycles))
+		mid := len(cycles) / 2
+		if len(cycles)%2 == 0 {
+			metrics.MedianCycleDays = float64(cycles[mid-1]+cycles[mid]) / 2
+		} else {
+			metrics.MedianCycleDays = float64(cycles[mid])
+		}
+	}
+
+	return metrics
+}
+
+// Show 显示流转统计
+func (m *FlowMetrics) Show() {
+	fmt.Println(ColorBold + "  ⏳ Flow & Time in State" + ColorReset)
+	fmt.Println()
+
+	if len(m.CycleTimes) > 0 {
+		fmt.Printf("  %s%-15s%s avg %s%.1fd%s, median %.1fd %s(%d finished)%s\n",
+			ColorDim, "Cycle time:", ColorReset,
+			ColorBold, m.AvgCycleDays, ColorReset,
+			m.MedianCycleDays,
+			ColorDim, len(m.CycleTimes), ColorReset)
+	} else {
+		fmt.Printf("  %s%-15s%s %sno feature has reached FINISHED yet%s\n", ColorDim, "Cycle time:", ColorReset, ColorDim, ColorReset)
+	}
+	fmt.Println()
+
+	bottleneck := FeatureStatus("")
+	if len(m.Bottlenecks) > 0 {
+		bottleneck = m.Bottlenecks[0].Status
+	}
+
+	fmt.Printf("  %s%-18s %8s %8s %9s  %s%s\n", ColorDim, "Status", "Avg", "Max", "Features", "Now", ColorReset)
+	for _, stage := range m.Stages {
+		marker := "  "
+		if stage.Status == bottleneck {
+			marker = ColorRed + "◀ bottleneck" + ColorReset
+		}
+		statusName := strings.ReplaceAll(string(stage.Status), "_", " ")
+		fmt.Printf("  %s%-18s%s %7.1fd %7dd %9d  %-3d %s\n",
+			GetStatusColor(stage.Status), statusName, ColorReset,
+			stage.AvgDays, stage.MaxDays, stage.Features, len(stage.Current), marker)
+	}
+}
+
+// CompactLine 返回紧凑视图中的一行流转摘要（没有数据时返回空字符串）
+func (m *FlowMetrics) CompactLine() string {
+	parts := []string{}
+	if len(m.CycleTimes) > 0 {
+		parts = append(parts, fmt.Sprintf("cycle time %.1fd avg", m.AvgCycleDays))
+	}
+	if len(m.Bottlenecks) > 0 {
+		top := m.Bottlenecks[0]
+		parts = append(parts, fmt.Sprintf("bottleneck %s (%.1fd avg)", top.Status, top.AvgDays))
+	}
+	return strings.Join(parts, " | ")
+}
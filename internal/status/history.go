@@ -0,0 +1,286 @@
+package status
+
+import (
+	"bufio"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// HistorySource 状态历史的来源
+type HistorySource string
+
+const (
+	HistoryFromChangelog HistorySource = "changelog" // ## Changelog 中的 "Status X → Y" 记录
+	HistoryFromGit       HistorySource = "git"       // git 历史中 "- Value:" 的变化
+	HistoryFromFile      HistorySource = "file"      // 只有当前状态和 Last Updated
+)
+
+// StatusEvent 一次状态变更
+type StatusEvent struct {
+	Date time.Time     `json:"date" yaml:"date"`
+	From FeatureStatus `json:"from" yaml:"from"`
+	To   FeatureStatus `json:"to" yaml:"to"`
+}
+
+// StateSpan feature 在某个状态停留的一段时间
+type StateSpan struct {
+	Status FeatureStatus `json:"status" yaml:"status"`
+	Start  time.Time     `json:"start" yaml:"start"`
+	End    time.Time     `json:"end" yaml:"end"`
+	Days   int           `json:"days" yaml:"days"`
+	Open   bool          `json:"open" yaml:"open"` // 当前仍处于该状态
+}
+
+// FeatureHistory 一个 feature 的状态时间线
+type FeatureHistory struct {
+	Feature   string        `json:"feature" yaml:"feature"`
+	Status    FeatureStatus `json:"status" yaml:"status"`
+	Source    HistorySource `json:"source" yaml:"source"`
+	Events    []StatusEvent `json:"events" yaml:"events"`
+	Spans     []StateSpan   `json:"spans" yaml:"spans"`
+	CycleDays int           `json:"cycle_days" yaml:"cycle_days"` // NOT_REVIEWED 到 FINISHED 的天数，未完成时为 -1
+}
+
+// CurrentSpan 返回当前状态的时间段（没有可用日期时返回 nil）
+func (h *FeatureHistory) CurrentSpan() *StateSpan {
+	if len(h.Spans) == 0 || !h.Spans[len(h.Spans)-1].Open {
+		return nil
+	}
+	return &h.Spans[len(h.Spans)-1]
+}
+
+// DaysInCurrentStatus 返回处于当前状态的天数（未知时返回 -1）
+func (h *FeatureHistory) DaysInCurrentStatus() int {
+	if span := h.CurrentSpan(); span != nil {
+		return span.Days
+	}
+	return -1
+}
+
+// TimeInState 返回在每个状态累计停留的天数
+func (h *FeatureHistory) TimeInState() map[FeatureStatus]int {
+	result := make(map[FeatureStatus]int)
+	for _, span := range h.Spans {
+		result[span.Status] += span.Days
+	}
+	return result
+}
+
+// gitCommand 执行 git 命令，测试中可替换
+var gitCommand = func(dir string, args ...string) ([]byte, error) {
+	return exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+}
+
+// HistoryBuilder 从 Changelog（或 git 历史）重建 feature 的状态时间线
+type HistoryBuilder struct {
+	fs     afero.Fs
+	useGit bool
+	now    time.Time
+}
+
+// NewHistoryBuilder 创建状态历史构建器
+// 使用真实文件系统时，Changelog 中没有状态变更的 feature 会回退到 git 历史
+func NewHistoryBuilder(fs afero.Fs) *HistoryBuilder {
+	useGit := fs == nil
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &HistoryBuilder{fs: fs, useGit: useGit, now: time.Now()}
+}
+
+// BuildAll 为所有 features 构建状态历史，按 feature key 排序
+func (b *HistoryBuilder) BuildAll(projectPath string, features []Feature) []*FeatureHistory {
+	histories := make([]*FeatureHistory, 0, len(features))
+	for _, feature := range features {
+		histories = append(histories, b.Build(projectPath, feature))
+	}
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].Feature < histories[j].Feature
+	})
+	return histories
+}
+
+// Build 构建单个 feature 的状态历史
+func (b *HistoryBuilder) Build(projectPath string, feature Feature) *FeatureHistory {
+	history := &FeatureHistory{
+		Feature:   feature.Name,
+		Status:    feature.Status,
+		Source:    HistoryFromFile,
+		Events:    []StatusEvent{},
+		Spans:     []StateSpan{},
+		CycleDays: -1,
+	}
+
+	var created time.Time
+	if detail, err := NewDetailParser(b.fs).ParseFeatureDetail(projectPath, feature.Name); err == nil {
+		history.Events, created = ParseStatusEvents(detail.Changelog)
+		if len(history.Events) > 0 {
+			history.Source = HistoryFromChangelog
+		}
+	}
+
+	if len(history.Events) == 0 && b.useGit {
+		relPath := filepath.Join("features", feature.Name+".md")
+		args := []string{"log", "--follow", "--reverse", "--format=commit %cs", "-p", "-U0", "--", relPath}
+		if out, err := gitCommand(projectPath, args...); err == nil {
+			if events, first := ParseGitStatusLog(string(out)); len(events) > 0 {
+				history.Events = events
+				history.Source = HistoryFromGit
+				if created.IsZero() || first.Before(created) {
+					created = first
+				}
+			}
+		}
+	}
+
+	history.Spans = buildSpans(history.Events, created, feature, b.now)
+	history.CycleDays = cycleDays(history.Events, created)
+	return history
+}
+
+// ParseStatusEvents 从 Changelog 中提取状态变更，同时返回最早一条记录的日期
+func ParseStatusEvents(changelog []string) ([]StatusEvent, time.Time) {
+	events := []StatusEvent{}
+	var first time.Time
+
+	for _, line := range changelog {
+		entry, ok := ParseChangelogEntry(line)
+		if !ok {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", entry.Date)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+		if entry.IsStatusChange() {
+			events = append(events, StatusEvent{Date: date, From: entry.From, To: entry.To})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+	return events, first
+}
+
+var (
+	gitCommitLineRegex = regexp.MustCompile(`^commit (\d{4}-\d{2}-\d{2})$`)
+	gitValueLineRegex  = regexp.MustCompile(`^\+-\s*Value:\s*([A-Z_]+)\s*$`)
+)
+
+// ParseGitStatusLog 解析 git log -p 的输出（格式 "commit YYYY-MM-DD"，从旧到新）
+// 每次 "- Value:" 的变化视为一次状态变更，同时返回文件首次提交的日期
+func ParseGitStatusLog(output string) ([]StatusEvent, time.Time) {
+	events := []StatusEvent{}
+	var first, date time.Time
+	var current FeatureStatus
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if matches := gitCommitLineRegex.FindStringSubmatch(line); matches != nil {
+			date, _ = time.Parse("2006-01-02", matches[1])
+			if first.IsZero() {
+				first = date
+			}
+			continue
+		}
+
+		matches := gitValueLineRegex.FindStringSubmatch(line)
+		if matches == nil || date.IsZero() {
+			continue
+		}
+
+		value := FeatureStatus(matches[1])
+		if current != "" && value != current {
+			events = append(events, StatusEvent{Date: date, From: current, To: value})
+		}
+		current = value
+	}
+
+	return events, first
+}
+
+// buildSpans 根据状态变更构建每个状态的时间段
+// 最后一个状态与文件中的当前状态不一致时（手工修改），以 Last Updated 作为当前状态的开始
+func buildSpans(events []StatusEvent, created time.Time, feature Feature, now time.Time) []StateSpan {
+	spans := []StateSpan{}
+	lastUpdated, lastUpdatedErr := time.Parse("2006-01-02", feature.LastUpdated)
+
+	if len(events) == 0 {
+		if lastUpdatedErr != nil {
+			return spans
+		}
+		return append(spans, newSpan(feature.Status, lastUpdated, now, true))
+	}
+
+	start := created
+	if start.IsZero() || start.After(events[0].Date) {
+		start = events[0].Date
+	}
+	status := events[0].From
+
+	for _, event := range events {
+		spans = append(spans, newSpan(status, start, event.Date, false))
+		status = event.To
+		start = event.Date
+	}
+
+	if status != feature.Status && feature.Status != StatusUnknown && lastUpdatedErr == nil && !lastUpdated.Before(start) {
+		spans = append(spans, newSpan(status, start, lastUpdated, false))
+		status = feature.Status
+		start = lastUpdated
+	}
+
+	return append(spans, newSpan(status, start, now, true))
+}
+
+// newSpan 创建时间段；当前状态为 FINISHED 时，完成之后的时间不计入
+func newSpan(status FeatureStatus, start, end time.Time, open bool) StateSpan {
+	if status == StatusFinished && open {
+		end = start
+	}
+	return StateSpan{
+		Status: status,
+		Start:  start,
+		End:    end,
+		Days:   daysBetween(start, end),
+		Open:   open,
+	}
+}
+
+// cycleDays 从创建（第一条记录）到首次 FINISHED 的天数，未完成时返回 -1
+func cycleDays(events []StatusEvent, created time.Time) int {
+	if len(events) == 0 {
+		return -1
+	}
+	start := created
+	if start.IsZero() || start.After(events[0].Date) {
+		start = events[0].Date
+	}
+	for _, event := range events {
+		if event.To == StatusFinished {
+			return daysBetween(start, event.Date)
+		}
+	}
+	return -1
+}
+
+// daysBetween 两个日期相差的整天数（不小于 0）
+func daysBetween(start, end time.Time) int {
+	days := int(end.Sub(start).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
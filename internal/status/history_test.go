@@ -0,0 +1,123 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const finishedFeature = `# search
+
+## Status
+- Value: FINISHED
+- Owner: bob
+- Last Updated: 2024-01-20
+
+## Changelog
+- 2024-01-01 (bob): Created
+- 2024-01-03 (bob): Status NOT_REVIEWED → UNDER_REVIEW
+- 2024-01-10 (bob): Status UNDER_REVIEW → READY_FOR_DESIGN
+- 2024-01-20 (bob): Status READY_FOR_DESIGN → FINISHED
+`
+
+func mustDate(s string) time.Time {
+	d, _ := time.Parse("2006-01-02", s)
+	return d
+}
+
+func TestHistoryBuilder_Build(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/p/features/search.md", []byte(finishedFeature), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/p/features/checkout.md", []byte(testFeature), 0644))
+
+	builder := NewHistoryBuilder(fs)
+	builder.now = mustDate("2024-02-15")
+
+	features, err := NewParser(fs).ParseFeaturesDir("/p")
+	require.NoError(t, err)
+	histories := builder.BuildAll("/p", features)
+	require.Len(t, histories, 2)
+
+	checkout, search := histories[0], histories[1]
+
+	assert.Equal(t, HistoryFromChangelog, search.Source)
+	assert.Equal(t, 19, search.CycleDays)
+	assert.Equal(t, map[FeatureStatus]int{
+		StatusNotReviewed:    2,
+		StatusUnderReview:    7,
+		StatusReadyForDesign: 10,
+		StatusFinished:       0,
+	}, search.TimeInState())
+	assert.Equal(t, 0, search.DaysInCurrentStatus())
+
+	assert.Equal(t, -1, checkout.CycleDays)
+	assert.Equal(t, StatusUnderDesign, checkout.CurrentSpan().Status)
+	assert.Equal(t, 45, checkout.DaysInCurrentStatus())
+}
+
+func TestParseGitStatusLog(t *testing.T) {
+	output := `commit 2024-03-01
+diff --git a/features/auth.md b/features/auth.md
+@@ -0,0 +1,5 @@
++# auth
++## Status
++- Value: NOT_REVIEWED
+commit 2024-03-04
+@@ -3 +3 @@
+-- Value: NOT_REVIEWED
++- Value: UNDER_REVIEW
+commit 2024-03-05
+@@ -5 +5 @@
+-- Owner:
++- Owner: alice
+`
+
+	events, first := ParseGitStatusLog(output)
+	assert.Equal(t, mustDate("2024-03-01"), first)
+	assert.Equal(t, []StatusEvent{
+		{Date: mustDate("2024-03-04"), From: StatusNotReviewed, To: StatusUnderReview},
+	}, events)
+}
+
+func TestAnalyzeFlow(t *testing.T) {
+	histories := []*FeatureHistory{
+		{Feature: "a", Status: StatusUnderReview, CycleDays: -1, Spans: []StateSpan{
+			{Status: StatusNotReviewed, Days: 2},
+			{Status: StatusUnderReview, Days: 20, Open: true},
+		}},
+		{Feature: "b", Status: StatusFinished, CycleDays: 12, Spans: []StateSpan{
+			{Status: StatusUnderReview, Days: 10},
+			{Status: StatusFinished, Days: 0, Open: true},
+		}},
+	}
+
+	metrics := AnalyzeFlow(histories)
+	require.Len(t, metrics.Stages, 3)
+	require.NotEmpty(t, metrics.Bottlenecks)
+	assert.Equal(t, StatusUnderReview, metrics.Bottlenecks[0].Status)
+	assert.Equal(t, 15.0, metrics.Bottlenecks[0].AvgDays)
+	assert.Equal(t, []string{"a"}, metrics.Bottlenecks[0].Current)
+	assert.Equal(t, map[string]int{"b": 12}, metrics.CycleTimes)
+	assert.Equal(t, 12.0, metrics.MedianCycleDays)
+}
+
+func TestAggregator_StaleUsesTimeInState(t *testing.T) {
+	features := []Feature{
+		{Name: "review", Status: StatusUnderReview, LastUpdated: "2020-01-01"},
+		{Name: "done", Status: StatusFinished, LastUpdated: "2020-01-01"},
+		{Name: "fresh", Status: StatusUnderDesign, LastUpdated: "2020-01-01"},
+	}
+	aggregator := NewAggregator(features)
+	aggregator.SetHistories([]*FeatureHistory{
+		{Feature: "review", Spans: []StateSpan{{Status: StatusUnderReview, Days: 40, Open: true}}},
+		{Feature: "fresh", Spans: []StateSpan{{Status: StatusUnderDesign, Days: 3, Open: true}}},
+	})
+
+	summary := aggregator.Aggregate()
+	require.Len(t, summary.StaleFeatures, 1)
+	assert.Equal(t, "review", summary.StaleFeatures[0].Name)
+	assert.Equal(t, 40, summary.DaysInStatus["review"])
+}
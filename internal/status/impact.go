@@ -0,0 +1,159 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ImpactedFeature 受上游 feature 变更影响的下游 feature
+type ImpactedFeature struct {
+	Key         string        `json:"key" yaml:"key"`
+	Status      FeatureStatus `json:"status" yaml:"status"`
+	Depth       int           `json:"depth" yaml:"depth"`               // 1 = 直接依赖上游 feature
+	Path        []string      `json:"path" yaml:"path"`                 // 从上游 feature 到该 feature 的依赖链
+	NeedsReview bool          `json:"needs_review" yaml:"needs_review"` // 已越过 DESIGNED，设计可能失效
+}
+
+// ImpactReport 一个 feature 的传递影响分析结果
+type ImpactReport struct {
+	Feature     string            `json:"feature" yaml:"feature"`
+	Status      FeatureStatus     `json:"status" yaml:"status"`
+	Impacted    []ImpactedFeature `json:"impacted" yaml:"impacted"`
+	NeedsReview []string          `json:"needs_review" yaml:"needs_review"`
+}
+
+// IsPastDesigned 状态是否已越过 DESIGNED（spec、实现或测试计划已基于设计产出）
+func IsPastDesigned(status FeatureStatus) bool {
+	switch status {
+	case StatusSpecReady, StatusImplementing, StatusFinished:
+		return true
+	}
+	return false
+}
+
+// Impact 沿反向依赖图做广度优先遍历，列出所有直接和间接依赖 featureKey 的 features
+// 每个 feature 只出现一次，深度取最短依赖链
+func (g *DependencyGraph) Impact(featureKey string) (*ImpactReport, error) {
+	root, exists := g.FeaturesByKey[featureKey]
+	if !exists {
+		return nil, fmt.Errorf("feature '%s' not found", featureKey)
+	}
+
+	report := &ImpactReport{
+		Feature:     featureKey,
+		Status:      root.Status,
+		Impacted:    []ImpactedFeature{},
+		NeedsReview: []string{},
+	}
+
+	paths := map[string][]string{featureKey: {featureKey}}
+	queue := []string{featureKey}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, dependent := range g.DependedBy[current] {
+			if _, seen := paths[dependent]; seen {
+				continue
+			}
+
+			path := append(append([]string{}, paths[current]...), dependent)
+			paths[dependent] = path
+			queue = append(queue, dependent)
+
+			feature := g.FeaturesByKey[dependent]
+			impacted := ImpactedFeature{
+				Key:         dependent,
+				Status:      feature.Status,
+				Depth:       len(path) - 1,
+				Path:        path,
+				NeedsReview: IsPastDesigned(feature.Status),
+			}
+			report.Impacted = append(report.Impacted, impacted)
+		}
+	}
+
+	sort.SliceStable(report.Impacted, func(i, j int) bool {
+		if report.Impacted[i].Depth != report.Impacted[j].Depth {
+			return report.Impacted[i].Depth < report.Impacted[j].Depth
+		}
+		return report.Impacted[i].Key < report.Impacted[j].Key
+	})
+
+	for _, impacted := range report.Impacted {
+		if impacted.NeedsReview {
+			report.NeedsReview = append(report.NeedsReview, impacted.Key)
+		}
+	}
+
+	return report, nil
+}
+
+// ImpactDisplay 负责展示影响分析结果
+type ImpactDisplay struct {
+	report *ImpactReport
+}
+
+// NewImpactDisplay 创建影响分析展示器
+func NewImpactDisplay(report *ImpactReport) *ImpactDisplay {
+	return &ImpactDisplay{report: report}
+}
+
+// Show 展示影响分析结果
+func (d *ImpactDisplay) Show() {
+	r := d.report
+
+	fmt.Println()
+	fmt.Printf("  %s💥 Impact of changing %s%s %s[%s]%s\n",
+		ColorBold, r.Feature, ColorReset,
+		GetStatusColor(r.Status), strings.ReplaceAll(string(r.Status), "_", " "), ColorReset)
+	fmt.Println()
+
+	if len(r.Impacted) == 0 {
+		fmt.Println(ColorDim + "  No features depend on this feature" + ColorReset)
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("  %-6s %-30s %-18s %s\n", "Depth", "Feature", "Status", "Via")
+	fmt.Println("  " + ColorDim + strings.Repeat("─", 70) + ColorReset)
+
+	for _, impacted := range r.Impacted {
+		statusName := strings.ReplaceAll(string(impacted.Status), "_", " ")
+		marker := "  "
+		if impacted.NeedsReview {
+			marker = "⚠️"
+		}
+
+		via := ""
+		if len(impacted.Path) > 2 {
+			via = strings.Join(impacted.Path[1:len(impacted.Path)-1], " → ")
+		}
+
+		fmt.Printf("  %-6d %s %-27s %s%-18s%s %s%s%s\n",
+			impacted.Depth,
+			marker, impacted.Key,
+			GetStatusColor(impacted.Status), statusName, ColorReset,
+			ColorDim, via, ColorReset)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %d downstream feature(s)", len(r.Impacted))
+	if len(r.NeedsReview) > 0 {
+		fmt.Printf(", %s%d past DESIGNED%s", ColorYellow, len(r.NeedsReview), ColorReset)
+	}
+	fmt.Println()
+
+	if len(r.NeedsReview) > 0 {
+		fmt.Println()
+		fmt.Println(ColorYellow + ColorBold + "  ⚠️  Specs and test plans to re-review" + ColorReset)
+		fmt.Println()
+		for _, key := range r.NeedsReview {
+			fmt.Printf("     • %s\n", key)
+		}
+	}
+
+	fmt.Println()
+}
@@ -42,13 +42,13 @@ var AllStatuses = []FeatureStatus{
 
 // Feature 表示一个 feature 及其状态信息
 type Feature struct {
-	Name         string
-	Status       FeatureStatus
-	Owner        string
-	LastUpdated  string
-	Reason       string
-	FilePath     string
-	Dependencies map[string]string // feature-key -> reason
+	Name         string            `json:"name" yaml:"name"`
+	Status       FeatureStatus     `json:"status" yaml:"status"`
+	Owner        string            `json:"owner" yaml:"owner"`
+	LastUpdated  string            `json:"last_updated" yaml:"last_updated"`
+	Reason       string            `json:"reason" yaml:"reason"`
+	FilePath     string            `json:"file_path" yaml:"file_path"`
+	Dependencies map[string]string `json:"dependencies" yaml:"dependencies"` // feature-key -> reason
 }
 
 // Parser 解析 feature 文件
@@ -250,16 +250,20 @@ func GetStatusColor(status FeatureStatus) string {
 
 // IsOld 检查 LastUpdated 是否超过指定天数
 func (f *Feature) IsOld(days int) bool {
+	return f.DaysSinceUpdate() > days
+}
+
+// DaysSinceUpdate 返回距 LastUpdated 的天数（未设置或无法解析时返回 -1）
+func (f *Feature) DaysSinceUpdate() int {
 	if f.LastUpdated == "" || f.LastUpdated == "YYYY-MM-DD" {
-		return false
+		return -1
 	}
 
 	// 尝试解析日期
 	date, err := time.Parse("2006-01-02", f.LastUpdated)
 	if err != nil {
-		return false
+		return -1
 	}
 
-	daysSince := time.Since(date).Hours() / 24
-	return int(daysSince) > days
+	return int(time.Since(date).Hours() / 24)
 }
@@ -0,0 +1,191 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultStaleDays 未配置时的过期天数
+const DefaultStaleDays = 30
+
+// staleDefaultKey stale_days 中适用于所有状态的键
+const staleDefaultKey = "default"
+
+// ownerWildcard wip_limits.owner 中适用于每个 owner 的键
+const ownerWildcard = "*"
+
+// StatusPolicy 状态过期阈值和 WIP 限制，对应配置文件中的 status 部分
+//
+//	status:
+//	  stale_days:
+//	    default: 30
+//	    UNDER_REVIEW: 5
+//	    IMPLEMENTING: 21
+//	  wip_limits:
+//	    status:
+//	      IMPLEMENTING: 3
+//	    owner:
+//	      "*": 2
+//	      alice: 3
+type StatusPolicy struct {
+	StaleDays map[string]int `json:"stale_days" yaml:"stale_days"` // 状态 -> 天数，"default" 适用于其余状态，0 表示不检查
+	WIPLimits WIPLimits      `json:"wip_limits" yaml:"wip_limits"`
+}
+
+// WIPLimits 进行中工作的数量上限
+type WIPLimits struct {
+	Status map[string]int `json:"status" yaml:"status"` // 状态 -> 最多 feature 数
+	Owner  map[string]int `json:"owner" yaml:"owner"`   // owner -> 最多进行中的 feature 数，"*" 适用于每个 owner
+}
+
+// DefaultStatusPolicy 默认策略：所有状态 30 天过期，没有 WIP 限制
+func DefaultStatusPolicy() *StatusPolicy {
+	return &StatusPolicy{
+		StaleDays: map[string]int{staleDefaultKey: DefaultStaleDays},
+	}
+}
+
+// StaleThreshold 返回状态的过期天数（0 表示不检查）
+// FINISHED 是终态，永远不会过期
+func (p *StatusPolicy) StaleThreshold(status FeatureStatus) int {
+	if status == StatusFinished {
+		return 0
+	}
+	if days, ok := p.StaleDays[string(status)]; ok {
+		return days
+	}
+	if days, ok := p.StaleDays[staleDefaultKey]; ok {
+		return days
+	}
+	return DefaultStaleDays
+}
+
+// OwnerLimit 返回 owner 的 WIP 上限（0 表示不限制）
+func (p *StatusPolicy) OwnerLimit(owner string) int {
+	if limit, ok := p.WIPLimits.Owner[owner]; ok {
+		return limit
+	}
+	return p.WIPLimits.Owner[ownerWildcard]
+}
+
+// Validate 检查配置中的状态名和数值是否有效
+func (p *StatusPolicy) Validate() error {
+	for key, days := range p.StaleDays {
+		if key != staleDefaultKey && !IsValidStatus(FeatureStatus(key)) {
+			return fmt.Errorf("stale_days: unknown status %q", key)
+		}
+		if days < 0 {
+			return fmt.Errorf("stale_days.%s: must not be negative", key)
+		}
+	}
+	for key, limit := range p.WIPLimits.Status {
+		if !IsValidStatus(FeatureStatus(key)) {
+			return fmt.Errorf("wip_limits.status: unknown status %q", key)
+		}
+		if limit < 0 {
+			return fmt.Errorf("wip_limits.status.%s: must not be negative", key)
+		}
+	}
+	for owner, limit := range p.WIPLimits.Owner {
+		if limit < 0 {
+			return fmt.Errorf("wip_limits.owner.%s: must not be negative", owner)
+		}
+	}
+	return nil
+}
+
+// ViolationKind 策略违规类型
+type ViolationKind string
+
+const (
+	ViolationStale     ViolationKind = "stale"      // 在某个状态停留超过阈值
+	ViolationWIPStatus ViolationKind = "wip_status" // 某个状态的 feature 数超过上限
+	ViolationWIPOwner  ViolationKind = "wip_owner"  // 某个 owner 进行中的 feature 数超过上限
+)
+
+// PolicyViolation 一条策略违规
+type PolicyViolation struct {
+	Kind     ViolationKind `json:"kind" yaml:"kind"`
+	Subject  string        `json:"subject" yaml:"subject"` // feature key、状态或 owner
+	Status   FeatureStatus `json:"status,omitempty" yaml:"status,omitempty"`
+	Limit    int           `json:"limit" yaml:"limit"`
+	Actual   int           `json:"actual" yaml:"actual"`
+	Features []string      `json:"features" yaml:"features"`
+}
+
+// String 返回可读的违规描述
+func (v PolicyViolation) String() string {
+	switch v.Kind {
+	case ViolationStale:
+		return fmt.Sprintf("%s has been %s for %dd (limit %dd)", v.Subject, v.Status, v.Actual, v.Limit)
+	case ViolationWIPStatus:
+		return fmt.Sprintf("%s has %d features (limit %d): %s", v.Subject, v.Actual, v.Limit, strings.Join(v.Features, ", "))
+	case ViolationWIPOwner:
+		return fmt.Sprintf("%s owns %d features in progress (limit %d): %s", v.Subject, v.Actual, v.Limit, strings.Join(v.Features, ", "))
+	default:
+		return fmt.Sprintf("%s: %s", v.Kind, v.Subject)
+	}
+}
+
+// isWorkInProgress 是否计入 owner 的 WIP（已开始且未完成，包括 BLOCKED）
+func isWorkInProgress(status FeatureStatus) bool {
+	switch status {
+	case StatusNotReviewed, StatusFinished, StatusUnknown:
+		return false
+	default:
+		return true
+	}
+}
+
+// checkWIPLimits 检查每个状态和每个 owner 的 WIP 上限
+func (p *StatusPolicy) checkWIPLimits(features []Feature) []PolicyViolation {
+	violations := []PolicyViolation{}
+
+	byStatus := make(map[FeatureStatus][]string)
+	byOwner := make(map[string][]string)
+	for _, feature := range features {
+		byStatus[feature.Status] = append(byStatus[feature.Status], feature.Name)
+		if feature.Owner != "" && isWorkInProgress(feature.Status) {
+			byOwner[feature.Owner] = append(byOwner[feature.Owner], feature.Name)
+		}
+	}
+
+	for _, status := range AllStatuses {
+		limit := p.WIPLimits.Status[string(status)]
+		names := byStatus[status]
+		if limit > 0 && len(names) > limit {
+			sort.Strings(names)
+			violations = append(violations, PolicyViolation{
+				Kind:     ViolationWIPStatus,
+				Subject:  string(status),
+				Status:   status,
+				Limit:    limit,
+				Actual:   len(names),
+				Features: names,
+			})
+		}
+	}
+
+	owners := make([]string, 0, len(byOwner))
+	for owner := range byOwner {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	for _, owner := range owners {
+		limit := p.OwnerLimit(owner)
+		names := byOwner[owner]
+		if limit > 0 && len(names) > limit {
+			sort.Strings(names)
+			violations = append(violations, PolicyViolation{
+				Kind:     ViolationWIPOwner,
+				Subject:  owner,
+				Limit:    limit,
+				Actual:   len(names),
+				Features: names,
+			})
+		}
+	}
+
+	return violations
+}
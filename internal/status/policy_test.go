@@ -0,0 +1,61 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusPolicy(t *testing.T) {
+	policy := DefaultStatusPolicy()
+	assert.Equal(t, DefaultStaleDays, policy.StaleThreshold(StatusUnderReview))
+
+	policy.StaleDays[string(StatusUnderReview)] = 5
+	policy.StaleDays[string(StatusImplementing)] = 21
+	policy.WIPLimits.Owner = map[string]int{"*": 1, "alice": 2}
+	require.NoError(t, policy.Validate())
+
+	assert.Equal(t, 5, policy.StaleThreshold(StatusUnderReview))
+	assert.Equal(t, 21, policy.StaleThreshold(StatusImplementing))
+	assert.Equal(t, DefaultStaleDays, policy.StaleThreshold(StatusDesigned))
+	assert.Equal(t, 0, policy.StaleThreshold(StatusFinished))
+	assert.Equal(t, 2, policy.OwnerLimit("alice"))
+	assert.Equal(t, 1, policy.OwnerLimit("bob"))
+
+	policy.StaleDays["REVIEWING"] = 3
+	assert.Error(t, policy.Validate())
+}
+
+func TestAggregator_PolicyViolations(t *testing.T) {
+	policy := DefaultStatusPolicy()
+	policy.StaleDays[string(StatusUnderReview)] = 5
+	policy.WIPLimits = WIPLimits{
+		Status: map[string]int{string(StatusImplementing): 1},
+		Owner:  map[string]int{"*": 1},
+	}
+
+	features := []Feature{
+		{Name: "review", Status: StatusUnderReview, Owner: "carol"},
+		{Name: "impl-a", Status: StatusImplementing, Owner: "bob"},
+		{Name: "impl-b", Status: StatusImplementing, Owner: "bob"},
+		{Name: "done", Status: StatusFinished, Owner: "bob"},
+	}
+	aggregator := NewAggregator(features)
+	aggregator.SetPolicy(policy)
+	aggregator.SetHistories([]*FeatureHistory{
+		{Feature: "review", Spans: []StateSpan{{Status: StatusUnderReview, Days: 6, Open: true}}},
+	})
+
+	summary := aggregator.Aggregate()
+	require.Len(t, summary.Violations, 3)
+
+	assert.Equal(t, ViolationStale, summary.Violations[0].Kind)
+	assert.Equal(t, "review has been UNDER_REVIEW for 6d (limit 5d)", summary.Violations[0].String())
+	assert.Equal(t, ViolationWIPStatus, summary.Violations[1].Kind)
+	assert.Equal(t, []string{"impl-a", "impl-b"}, summary.Violations[1].Features)
+	assert.Equal(t, ViolationWIPOwner, summary.Violations[2].Kind)
+	assert.Equal(t, "bob", summary.Violations[2].Subject)
+
+	assert.Contains(t, summary.GetTopInsights(), "🚦 WIP limit exceeded: bob owns 2 features in progress (limit 1): impl-a, impl-b")
+}
@@ -0,0 +1,147 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ReadinessPolicy 依赖未就绪时推进 feature 的处理策略
+type ReadinessPolicy string
+
+const (
+	PolicyWarn   ReadinessPolicy = "warn"   // 允许推进，但给出警告
+	PolicyRefuse ReadinessPolicy = "refuse" // 拒绝推进
+)
+
+// ParseReadinessPolicy 解析策略名称（空字符串视为 warn）
+func ParseReadinessPolicy(policy string) (ReadinessPolicy, error) {
+	switch ReadinessPolicy(strings.ToLower(strings.TrimSpace(policy))) {
+	case "", PolicyWarn:
+		return PolicyWarn, nil
+	case PolicyRefuse:
+		return PolicyRefuse, nil
+	default:
+		return "", fmt.Errorf("unsupported dependency policy %q (use warn or refuse)", policy)
+	}
+}
+
+// UnmetDependency 未满足要求的依赖
+type UnmetDependency struct {
+	Key     string        `json:"key" yaml:"key"`
+	Status  FeatureStatus `json:"status" yaml:"status"`   // 依赖当前状态（缺失时为空）
+	Missing bool          `json:"missing" yaml:"missing"` // 依赖的 feature-key 不存在
+}
+
+// DependencyReadiness 一个 feature 进入目标状态时的依赖就绪情况
+type DependencyReadiness struct {
+	Feature  string            `json:"feature" yaml:"feature"`
+	Target   FeatureStatus     `json:"target" yaml:"target"`     // 评估的目标状态
+	Required FeatureStatus     `json:"required" yaml:"required"` // 依赖至少需要达到的状态
+	Ready    bool              `json:"ready" yaml:"ready"`
+	Unmet    []UnmetDependency `json:"unmet" yaml:"unmet"`
+}
+
+// RequiredDependencyStatus 进入 target 前依赖至少需要达到的状态
+// 开始设计需要依赖已完成评审；产出 spec 和实现需要依赖已完成设计
+// 返回空字符串表示 target 不受依赖约束
+func RequiredDependencyStatus(target FeatureStatus) FeatureStatus {
+	switch target {
+	case StatusUnderDesign, StatusDesigned:
+		return StatusReadyForDesign
+	case StatusSpecReady, StatusImplementing, StatusFinished:
+		return StatusDesigned
+	default:
+		return ""
+	}
+}
+
+// nextGate 返回 feature 当前阶段需要通过的下一个依赖关口
+func nextGate(status FeatureStatus) FeatureStatus {
+	switch status {
+	case StatusUnderDesign, StatusDesigned, StatusSpecReady, StatusImplementing:
+		return StatusSpecReady
+	case StatusFinished:
+		return ""
+	default:
+		return StatusUnderDesign
+	}
+}
+
+// ReadinessFor 计算 feature 进入 target 时的依赖就绪情况
+// 依赖不存在、BLOCKED、UNKNOWN 或尚未达到要求状态都视为未满足
+func (g *DependencyGraph) ReadinessFor(featureKey string, target FeatureStatus) *DependencyReadiness {
+	readiness := &DependencyReadiness{
+		Feature:  featureKey,
+		Target:   target,
+		Required: RequiredDependencyStatus(target),
+		Ready:    true,
+		Unmet:    []UnmetDependency{},
+	}
+
+	if readiness.Required == "" {
+		return readiness
+	}
+
+	required := GetStatusProgress(readiness.Required)
+	for _, depKey := range g.DependsOn[featureKey] {
+		dep, exists := g.FeaturesByKey[depKey]
+		if !exists {
+			readiness.Unmet = append(readiness.Unmet, UnmetDependency{Key: depKey, Missing: true})
+			continue
+		}
+		if !IsValidStatus(dep.Status) || dep.Status == StatusBlocked || GetStatusProgress(dep.Status) < required {
+			readiness.Unmet = append(readiness.Unmet, UnmetDependency{Key: depKey, Status: dep.Status})
+		}
+	}
+
+	readiness.Ready = len(readiness.Unmet) == 0
+	return readiness
+}
+
+// Readiness 计算 feature 进入下一个阶段时的依赖就绪情况
+func (g *DependencyGraph) Readiness(featureKey string) *DependencyReadiness {
+	var status FeatureStatus
+	if feature, exists := g.FeaturesByKey[featureKey]; exists {
+		status = feature.Status
+	}
+	return g.ReadinessFor(featureKey, nextGate(status))
+}
+
+// AllReadiness 返回所有 features 的就绪情况（按 feature-key 排序）
+func (g *DependencyGraph) AllReadiness() []*DependencyReadiness {
+	keys := make([]string, 0, len(g.FeaturesByKey))
+	for key := range g.FeaturesByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]*DependencyReadiness, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, g.Readiness(key))
+	}
+	return result
+}
+
+// Describe 返回未满足依赖的简短描述，如 "auth (NOT_REVIEWED), billing (missing)"
+func (r *DependencyReadiness) Describe() string {
+	parts := make([]string, len(r.Unmet))
+	for i, dep := range r.Unmet {
+		if dep.Missing {
+			parts[i] = fmt.Sprintf("%s (missing)", dep.Key)
+		} else {
+			parts[i] = fmt.Sprintf("%s (%s)", dep.Key, dep.Status)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ReadinessError 依赖未就绪且策略为 refuse 时返回
+type ReadinessError struct {
+	Readiness *DependencyReadiness
+}
+
+func (e *ReadinessError) Error() string {
+	return fmt.Sprintf("cannot move %s to %s: dependencies must be at least %s: %s",
+		e.Readiness.Feature, e.Readiness.Target, e.Readiness.Required, e.Readiness.Describe())
+}
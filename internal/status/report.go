@@ -0,0 +1,136 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GarrickZ2/archie/internal/tasks"
+)
+
+// OutputFormat 输出格式
+type OutputFormat string
+
+const (
+	FormatText OutputFormat = "text"
+	FormatJSON OutputFormat = "json"
+	FormatYAML OutputFormat = "yaml"
+)
+
+// ParseOutputFormat 解析输出格式（空字符串视为 text）
+func ParseOutputFormat(format string) (OutputFormat, error) {
+	switch OutputFormat(format) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON, FormatYAML:
+		return OutputFormat(format), nil
+	case "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q (expected text, json or yaml)", format)
+	}
+}
+
+// DependencyGraphReport 依赖图的可序列化表示
+type DependencyGraphReport struct {
+	DependsOn        map[string][]string    `json:"depends_on" yaml:"depends_on"`
+	DependedBy       map[string][]string    `json:"depended_by" yaml:"depended_by"`
+	NoDependencies   []string               `json:"no_dependencies" yaml:"no_dependencies"`
+	HasDependencies  []string               `json:"has_dependencies" yaml:"has_dependencies"`
+	Components       [][]string             `json:"strongly_connected_components" yaml:"strongly_connected_components"`
+	Cycles           [][]string             `json:"cycles" yaml:"cycles"`
+	TopologicalOrder []string               `json:"topological_order" yaml:"topological_order"`
+	Unordered        []string               `json:"unordered" yaml:"unordered"` // 未能进入拓扑排序的 features
+	Readiness        []*DependencyReadiness `json:"readiness" yaml:"readiness"` // 每个 feature 进入下一阶段的依赖就绪情况
+}
+
+// Report 生成依赖图的可序列化表示
+func (g *DependencyGraph) Report() *DependencyGraphReport {
+	order, unordered := g.topologicalSort()
+	return &DependencyGraphReport{
+		DependsOn:        g.DependsOn,
+		DependedBy:       g.featureDependents(),
+		NoDependencies:   nonNilStrings(g.NoDependencies),
+		HasDependencies:  nonNilStrings(g.HasDependencies),
+		Components:       g.Components,
+		Cycles:           g.CircularDeps,
+		TopologicalOrder: order,
+		Unordered:        unordered,
+		Readiness:        g.AllReadiness(),
+	}
+}
+
+// featureDependents 返回存在的 features 的反向依赖；指向不存在 feature 的依赖
+// 已在 readiness 中标记为 missing，不作为图中的节点输出
+func (g *DependencyGraph) featureDependents() map[string][]string {
+	dependents := make(map[string][]string, len(g.DependedBy))
+	for key, features := range g.DependedBy {
+		if _, exists := g.FeaturesByKey[key]; exists {
+			dependents[key] = features
+		}
+	}
+	return dependents
+}
+
+// OverviewReport 项目整体状态报告
+type OverviewReport struct {
+	Summary      *Summary                  `json:"summary" yaml:"summary"`
+	Insights     []string                  `json:"insights" yaml:"insights"`
+	Features     []Feature                 `json:"features" yaml:"features"`
+	Dependencies *DependencyGraphReport    `json:"dependencies" yaml:"dependencies"`
+	Tasks        map[string]tasks.Progress `json:"tasks" yaml:"tasks"` // feature-key -> tasks.md 完成情况
+	TaskIssues   []tasks.Issue             `json:"task_issues" yaml:"task_issues"`
+	Blockers     []Blocker                 `json:"blockers" yaml:"blockers"`
+	BlockerCheck *BlockerCheck             `json:"blocker_check" yaml:"blocker_check"`
+	Flow         *FlowMetrics              `json:"flow" yaml:"flow"` // 状态历史、停留时间和周期时间
+}
+
+// NewOverviewReport 根据 features、tasks.md、blocker.md 和状态历史生成整体状态报告
+func NewOverviewReport(features []Feature, taskList *tasks.TaskList, blockers []Blocker, histories []*FeatureHistory, policy *StatusPolicy) *OverviewReport {
+	aggregator := NewAggregator(features)
+	aggregator.SetHistories(histories)
+	aggregator.SetPolicy(policy)
+	summary := aggregator.Aggregate()
+	taskIssues := taskList.Validate()
+	if taskIssues == nil {
+		taskIssues = []tasks.Issue{}
+	}
+	return &OverviewReport{
+		Summary:      summary,
+		Insights:     summary.GetTopInsights(),
+		Features:     SortFeaturesByStatus(features),
+		Dependencies: BuildDependencyGraph(features).Report(),
+		Tasks:        taskList.ProgressByFeature(),
+		TaskIssues:   taskIssues,
+		Blockers:     blockers,
+		BlockerCheck: CrossCheckBlockers(features, blockers),
+		Flow:         AnalyzeFlow(histories),
+	}
+}
+
+// WriteFormatted 以 JSON 或 YAML 格式输出 v
+func WriteFormatted(w io.Writer, format OutputFormat, v interface{}) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(v)
+	default:
+		return fmt.Errorf("format %q is not a machine-readable format", format)
+	}
+}
+
+// nonNilStrings 保证序列化时输出 [] 而不是 null
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/GarrickZ2/archie/internal/tasks"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    OutputFormat
+		wantErr bool
+	}{
+		{"", FormatText, false},
+		{"text", FormatText, false},
+		{"json", FormatJSON, false},
+		{"yaml", FormatYAML, false},
+		{"yml", FormatYAML, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseOutputFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteFormatted(t *testing.T) {
+	features := []Feature{
+		{Name: "auth", Status: StatusDesigned, Dependencies: map[string]string{}},
+		{Name: "checkout", Status: StatusNotReviewed, Dependencies: map[string]string{"auth": "login"}},
+	}
+	report := NewOverviewReport(features, tasks.Parse(""), nil, nil, DefaultStatusPolicy())
+
+	tests := []struct {
+		format    OutputFormat
+		unmarshal func([]byte, interface{}) error
+		wantErr   bool
+	}{
+		{FormatJSON, json.Unmarshal, false},
+		{FormatYAML, yaml.Unmarshal, false},
+		{FormatText, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteFormatted(&buf, tt.format, report)
+			if tt.wantErr {
+				assert.Error(t, err, "text is not a machine-readable format")
+				return
+			}
+			require.NoError(t, err)
+
+			var decoded map[string]interface{}
+			require.NoError(t, tt.unmarshal(buf.Bytes(), &decoded), buf.String())
+			summary, ok := decoded["summary"].(map[string]interface{})
+			require.True(t, ok, "summary should be an object")
+			assert.EqualValues(t, 2, summary["total_features"])
+			assert.Len(t, decoded["features"], 2)
+
+			dependencies, ok := decoded["dependencies"].(map[string]interface{})
+			require.True(t, ok, "dependencies should be an object")
+			assert.Equal(t, []interface{}{"auth", "checkout"}, dependencies["topological_order"])
+			assert.Equal(t, []interface{}{}, dependencies["cycles"], "an empty list must not be null")
+		})
+	}
+}
+
+func TestDependencyGraphReport_MissingDependency(t *testing.T) {
+	features := []Feature{
+		{Name: "auth", Status: StatusDesigned, Dependencies: map[string]string{}},
+		{Name: "checkout", Status: StatusNotReviewed, Dependencies: map[string]string{"auth": "login", "zz": "unknown"}},
+	}
+	report := BuildDependencyGraph(features).Report()
+
+	// Only features are nodes of the reverse graph; zz is reported as a missing dependency
+	assert.Equal(t, map[string][]string{"auth": {"checkout"}}, report.DependedBy)
+	assert.Equal(t, []string{"auth", "zz"}, report.DependsOn["checkout"])
+
+	var missing []string
+	for _, readiness := range report.Readiness {
+		for _, dep := range readiness.Unmet {
+			if dep.Missing {
+				missing = append(missing, dep.Key)
+			}
+		}
+	}
+	assert.Equal(t, []string{"zz"}, missing)
+}
@@ -0,0 +1,168 @@
+package status
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// transitions 允许的状态流转（BLOCKED 的进出单独处理）
+var transitions = map[FeatureStatus][]FeatureStatus{
+	StatusNotReviewed:    {StatusUnderReview},
+	StatusUnderReview:    {StatusReadyForDesign},
+	StatusReadyForDesign: {StatusUnderDesign, StatusDesigned},
+	StatusUnderDesign:    {StatusDesigned},
+	StatusDesigned:       {StatusSpecReady, StatusImplementing, StatusUnderDesign},
+	StatusSpecReady:      {StatusImplementing},
+	StatusImplementing:   {StatusFinished},
+	StatusFinished:       {},
+}
+
+// TransitionError 非法状态流转错误
+type TransitionError struct {
+	From    FeatureStatus
+	To      FeatureStatus
+	Allowed []FeatureStatus
+	Message string
+}
+
+func (e *TransitionError) Error() string {
+	msg := fmt.Sprintf("invalid transition %s → %s", e.From, e.To)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if len(e.Allowed) > 0 {
+		names := make([]string, len(e.Allowed))
+		for i, s := range e.Allowed {
+			names[i] = string(s)
+		}
+		msg += fmt.Sprintf(" (allowed: %s)", strings.Join(names, ", "))
+	}
+	return msg
+}
+
+// AllowedTransitions 返回从 from 出发允许的目标状态
+// previous 是进入 BLOCKED 之前的状态（仅 from 为 BLOCKED 时使用）
+func AllowedTransitions(from, previous FeatureStatus) []FeatureStatus {
+	if from == StatusBlocked {
+		if IsValidStatus(previous) && previous != StatusBlocked {
+			return []FeatureStatus{previous}
+		}
+		return []FeatureStatus{}
+	}
+
+	allowed := append([]FeatureStatus{}, transitions[from]...)
+	if from != StatusFinished && IsValidStatus(from) {
+		allowed = append(allowed, StatusBlocked)
+	}
+	return allowed
+}
+
+// ValidateTransition 校验状态流转是否合法
+// 进入 BLOCKED 必须提供 reason；离开 BLOCKED 只能回到之前的状态
+func ValidateTransition(from, to, previous FeatureStatus, reason string) error {
+	if !IsValidStatus(to) {
+		return fmt.Errorf("unknown status %q", to)
+	}
+
+	if !IsValidStatus(from) {
+		return &TransitionError{From: from, To: to, Message: "current status is not a valid state; fix the feature file first"}
+	}
+
+	if from == to {
+		return &TransitionError{From: from, To: to, Message: "feature is already in this status"}
+	}
+
+	if to == StatusBlocked && strings.TrimSpace(reason) == "" {
+		return &TransitionError{From: from, To: to, Message: "a reason is required when blocking a feature"}
+	}
+
+	if from == StatusBlocked && !IsValidStatus(previous) {
+		return &TransitionError{From: from, To: to, Message: "cannot determine the status before BLOCKED (no status change in Changelog)"}
+	}
+
+	allowed := AllowedTransitions(from, previous)
+	for _, s := range allowed {
+		if s == to {
+			return nil
+		}
+	}
+
+	return &TransitionError{From: from, To: to, Allowed: allowed}
+}
+
+// ParseStatusName 将用户输入解析为状态（忽略大小写，支持 - 和空格）
+func ParseStatusName(input string) FeatureStatus {
+	name := strings.ToUpper(strings.TrimSpace(input))
+	name = strings.NewReplacer("-", "_", " ", "_").Replace(name)
+	return FeatureStatus(name)
+}
+
+// ChangelogEntry 表示 Changelog 中的一条记录
+// 格式: - YYYY-MM-DD (who): <description>
+type ChangelogEntry struct {
+	Date        string
+	Author      string
+	Description string
+
+	// 状态变更记录: Status FROM → TO: reason
+	From   FeatureStatus
+	To     FeatureStatus
+	Reason string
+}
+
+// IsStatusChange 是否为状态变更记录
+func (e ChangelogEntry) IsStatusChange() bool {
+	return e.To != ""
+}
+
+var (
+	changelogEntryRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s*(?:\(([^)]*)\))?\s*:\s*(.*)$`)
+	statusChangeRegex   = regexp.MustCompile(`^Status\s+([A-Z_]+)\s*(?:→|->)\s*([A-Z_]+)(?:\s*:\s*(.*))?$`)
+)
+
+// ParseChangelogEntry 解析一条 Changelog 记录（不含前导 "- "）
+func ParseChangelogEntry(entry string) (ChangelogEntry, bool) {
+	matches := changelogEntryRegex.FindStringSubmatch(strings.TrimSpace(entry))
+	if matches == nil {
+		return ChangelogEntry{}, false
+	}
+
+	result := ChangelogEntry{
+		Date:        matches[1],
+		Author:      strings.TrimSpace(matches[2]),
+		Description: strings.TrimSpace(matches[3]),
+	}
+
+	if sm := statusChangeRegex.FindStringSubmatch(result.Description); sm != nil {
+		result.From = FeatureStatus(sm[1])
+		result.To = FeatureStatus(sm[2])
+		result.Reason = strings.TrimSpace(sm[3])
+	}
+
+	return result, true
+}
+
+// FormatStatusChange 生成状态变更的 Changelog 描述
+func FormatStatusChange(from, to FeatureStatus, reason string) string {
+	desc := fmt.Sprintf("Status %s → %s", from, to)
+	if reason != "" {
+		desc += ": " + reason
+	}
+	return desc
+}
+
+// StatusBeforeBlocked 从 Changelog 中找出最近一次进入 BLOCKED 之前的状态
+func StatusBeforeBlocked(changelog []string) FeatureStatus {
+	var previous FeatureStatus
+	for _, line := range changelog {
+		entry, ok := ParseChangelogEntry(line)
+		if !ok || !entry.IsStatusChange() {
+			continue
+		}
+		if entry.To == StatusBlocked && entry.From != StatusBlocked {
+			previous = entry.From
+		}
+	}
+	return previous
+}
@@ -0,0 +1,260 @@
+package status
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// StatusChange 描述一次状态变更请求
+type StatusChange struct {
+	To     FeatureStatus
+	Reason string
+	Author string
+	Date   time.Time
+	Policy ReadinessPolicy // 依赖未就绪时的处理策略，默认 warn
+}
+
+// StatusChangeResult 状态变更结果
+type StatusChangeResult struct {
+	FeatureKey     string
+	FilePath       string
+	From           FeatureStatus
+	To             FeatureStatus
+	ChangelogEntry string
+	Readiness      *DependencyReadiness // 目标状态的依赖就绪情况（warn 策略下可能未就绪）
+}
+
+// StatusUpdater 按状态机规则修改 feature 文件的状态
+type StatusUpdater struct {
+	fs           afero.Fs
+	detailParser *DetailParser
+}
+
+// NewStatusUpdater 创建状态更新器
+func NewStatusUpdater(fs afero.Fs) *StatusUpdater {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &StatusUpdater{
+		fs:           fs,
+		detailParser: NewDetailParser(fs),
+	}
+}
+
+// SetStatus 校验并执行状态变更
+// 更新 Status section 的 Value / Last Updated / Reason，并追加 Changelog 记录
+func (u *StatusUpdater) SetStatus(projectPath, featureKey string, change StatusChange) (*StatusChangeResult, error) {
+	detail, err := u.detailParser.ParseFeatureDetail(projectPath, featureKey)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := StatusBeforeBlocked(detail.Changelog)
+	if err := ValidateTransition(detail.Status, change.To, previous, change.Reason); err != nil {
+		return nil, err
+	}
+
+	readiness, err := u.checkReadiness(projectPath, featureKey, change)
+	if err != nil {
+		return nil, err
+	}
+
+	if change.Date.IsZero() {
+		change.Date = time.Now()
+	}
+	if change.Author == "" {
+		change.Author = "archie"
+	}
+
+	date := change.Date.Format("2006-01-02")
+	entry := fmt.Sprintf("%s (%s): %s", date, change.Author, FormatStatusChange(detail.Status, change.To, change.Reason))
+
+	content, err := afero.ReadFile(u.fs, detail.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature file: %w", err)
+	}
+
+	updated := updateStatusSection(string(content), map[string]string{
+		"Value":        string(change.To),
+		"Last Updated": date,
+		"Reason":       change.Reason,
+	})
+	updated = appendChangelogEntry(updated, entry)
+
+	if err := afero.WriteFile(u.fs, detail.FilePath, []byte(updated), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write feature file: %w", err)
+	}
+
+	return &StatusChangeResult{
+		FeatureKey:     featureKey,
+		FilePath:       filepath.Clean(detail.FilePath),
+		From:           detail.Status,
+		To:             change.To,
+		ChangelogEntry: entry,
+		Readiness:      readiness,
+	}, nil
+}
+
+// checkReadiness 检查目标状态的依赖就绪情况，refuse 策略下未就绪返回 ReadinessError
+func (u *StatusUpdater) checkReadiness(projectPath, featureKey string, change StatusChange) (*DependencyReadiness, error) {
+	features, err := NewParser(u.fs).ParseFeaturesDir(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	readiness := BuildDependencyGraph(features).ReadinessFor(featureKey, change.To)
+	if !readiness.Ready && change.Policy == PolicyRefuse {
+		return nil, &ReadinessError{Readiness: readiness}
+	}
+	return readiness, nil
+}
+
+// AppendChangelog 在 feature 的 Changelog 中追加一条普通记录（不改变状态）
+func (u *StatusUpdater) AppendChangelog(projectPath, featureKey, author string, date time.Time, description string) (string, error) {
+	detail, err := u.detailParser.ParseFeatureDetail(projectPath, featureKey)
+	if err != nil {
+		return "", err
+	}
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+	if author == "" {
+		author = "archie"
+	}
+	entry := fmt.Sprintf("%s (%s): %s", date.Format("2006-01-02"), author, description)
+
+	content, err := afero.ReadFile(u.fs, detail.FilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read feature file: %w", err)
+	}
+
+	updated := appendChangelogEntry(string(content), entry)
+	if err := afero.WriteFile(u.fs, detail.FilePath, []byte(updated), 0644); err != nil {
+		return "", fmt.Errorf("failed to write feature file: %w", err)
+	}
+
+	return entry, nil
+}
+
+// statusFieldOrder Status section 中字段的标准顺序
+var statusFieldOrder = []string{"Value", "Owner", "Last Updated", "Reason"}
+
+// updateStatusSection 更新 ## Status section 中的字段，缺失的字段追加到 section 末尾
+func updateStatusSection(content string, fields map[string]string) string {
+	lines := strings.Split(content, "\n")
+	start, end := findSection(lines, "Status")
+
+	if start == -1 {
+		// 没有 Status section：插入到标题之后
+		var section []string
+		section = append(section, "## Status")
+		for _, name := range statusFieldOrder {
+			if value, ok := fields[name]; ok {
+				section = append(section, formatField(name, value))
+			}
+		}
+		section = append(section, "")
+		insertAt := 0
+		if len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), "# ") {
+			insertAt = 1
+			section = append([]string{""}, section...)
+		}
+		return strings.Join(insertLines(lines, insertAt, section), "\n")
+	}
+
+	written := make(map[string]bool)
+	lastField := start
+	for i := start + 1; i < end; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		for name, value := range fields {
+			if strings.HasPrefix(trimmed, "- "+name+":") {
+				lines[i] = formatField(name, value)
+				written[name] = true
+			}
+		}
+		if strings.HasPrefix(trimmed, "- ") {
+			lastField = i
+		}
+	}
+
+	var missing []string
+	for _, name := range statusFieldOrder {
+		if value, ok := fields[name]; ok && !written[name] {
+			missing = append(missing, formatField(name, value))
+		}
+	}
+	if len(missing) > 0 {
+		lines = insertLines(lines, lastField+1, missing)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// appendChangelogEntry 在 ## Changelog section 末尾追加一条记录
+// 模板占位记录（YYYY-MM-DD 开头）会被替换
+func appendChangelogEntry(content, entry string) string {
+	lines := strings.Split(content, "\n")
+	start, end := findSection(lines, "Changelog")
+
+	if start == -1 {
+		trimmed := strings.TrimRight(content, "\n")
+		return trimmed + "\n\n## Changelog\n- " + entry + "\n"
+	}
+
+	lastItem := start
+	for i := start + 1; i < end; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "- YYYY-MM-DD") {
+			lines[i] = "- " + entry
+			return strings.Join(lines, "\n")
+		}
+		if strings.HasPrefix(trimmed, "- ") {
+			lastItem = i
+		}
+	}
+
+	return strings.Join(insertLines(lines, lastItem+1, []string{"- " + entry}), "\n")
+}
+
+// findSection 返回 ## <name> section 的起始行和结束行（不含）
+func findSection(lines []string, name string) (int, int) {
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if start == -1 {
+			if trimmed == "## "+name {
+				start = i
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "## ") || strings.HasPrefix(trimmed, "# ") {
+			return start, i
+		}
+	}
+	if start == -1 {
+		return -1, -1
+	}
+	return start, len(lines)
+}
+
+// insertLines 在 index 位置插入多行
+func insertLines(lines []string, index int, insert []string) []string {
+	result := make([]string, 0, len(lines)+len(insert))
+	result = append(result, lines[:index]...)
+	result = append(result, insert...)
+	result = append(result, lines[index:]...)
+	return result
+}
+
+// formatField 格式化 "- Name: value" 字段
+func formatField(name, value string) string {
+	if value == "" {
+		return "- " + name + ":"
+	}
+	return "- " + name + ": " + value
+}
@@ -0,0 +1,135 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFeature = `# checkout
+
+## Status
+- Value: UNDER_DESIGN
+- Owner: alice
+- Last Updated: 2024-01-01
+- Reason: design started
+
+## Summary
+- One-liner: checkout
+
+## Changelog
+- 2024-01-01 (alice): Status READY_FOR_DESIGN → UNDER_DESIGN
+`
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     FeatureStatus
+		to       FeatureStatus
+		previous FeatureStatus
+		reason   string
+		wantErr  bool
+	}{
+		{"forward", StatusNotReviewed, StatusUnderReview, "", "", false},
+		{"skip ahead", StatusNotReviewed, StatusDesigned, "", "", true},
+		{"block with reason", StatusDesigned, StatusBlocked, "", "waiting", false},
+		{"block without reason", StatusDesigned, StatusBlocked, "", "", true},
+		{"block finished", StatusFinished, StatusBlocked, "", "late bug", true},
+		{"unblock to previous", StatusBlocked, StatusDesigned, StatusDesigned, "", false},
+		{"unblock elsewhere", StatusBlocked, StatusImplementing, StatusDesigned, "", true},
+		{"unblock unknown previous", StatusBlocked, StatusDesigned, "", "", true},
+		{"same status", StatusDesigned, StatusDesigned, "", "", true},
+		{"unknown target", StatusDesigned, "DONE", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransition(tt.from, tt.to, tt.previous, tt.reason)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseChangelogEntry(t *testing.T) {
+	entry, ok := ParseChangelogEntry("2024-01-02 (bob): Status DESIGNED → BLOCKED: waiting on legal")
+	require.True(t, ok)
+	assert.Equal(t, "2024-01-02", entry.Date)
+	assert.Equal(t, "bob", entry.Author)
+	assert.Equal(t, StatusDesigned, entry.From)
+	assert.Equal(t, StatusBlocked, entry.To)
+	assert.Equal(t, "waiting on legal", entry.Reason)
+
+	entry, ok = ParseChangelogEntry("2024-01-02 (bob): added requirements")
+	require.True(t, ok)
+	assert.False(t, entry.IsStatusChange())
+
+	_, ok = ParseChangelogEntry("YYYY-MM-DD (who): <one-line change description>")
+	assert.False(t, ok)
+}
+
+func TestStatusUpdater_BlockAndUnblock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/p/features/checkout.md", []byte(testFeature), 0644))
+
+	updater := NewStatusUpdater(fs)
+	day := time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	result, err := updater.SetStatus("/p", "checkout", StatusChange{To: StatusBlocked, Reason: "waiting on legal", Author: "bob", Date: day})
+	require.NoError(t, err)
+	assert.Equal(t, StatusUnderDesign, result.From)
+
+	detail, err := NewDetailParser(fs).ParseFeatureDetail("/p", "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, StatusBlocked, detail.Status)
+	assert.Equal(t, "2024-02-03", detail.LastUpdated)
+	assert.Equal(t, "waiting on legal", detail.Reason)
+	assert.Equal(t, "alice", detail.Owner)
+	assert.Equal(t, "2024-02-03 (bob): Status UNDER_DESIGN → BLOCKED: waiting on legal", detail.Changelog[len(detail.Changelog)-1])
+
+	// Unblocking may only return to UNDER_DESIGN
+	_, err = updater.SetStatus("/p", "checkout", StatusChange{To: StatusDesigned, Author: "bob", Date: day})
+	assert.Error(t, err)
+
+	_, err = updater.SetStatus("/p", "checkout", StatusChange{To: StatusUnderDesign, Author: "bob", Date: day})
+	require.NoError(t, err)
+
+	detail, err = NewDetailParser(fs).ParseFeatureDetail("/p", "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, StatusUnderDesign, detail.Status)
+	assert.Equal(t, "", detail.Reason)
+	assert.Len(t, detail.Changelog, 3)
+}
+
+func TestAppendChangelogEntry_ReplacesPlaceholder(t *testing.T) {
+	content := "# x\n\n## Changelog\n- YYYY-MM-DD (who): <one-line change description>\n"
+	updated := appendChangelogEntry(content, "2024-01-01 (a): created")
+	assert.Equal(t, "# x\n\n## Changelog\n- 2024-01-01 (a): created\n", updated)
+
+	updated = appendChangelogEntry("# x\n\n## Status\n- Value: DESIGNED\n", "2024-01-01 (a): created")
+	assert.Equal(t, "# x\n\n## Status\n- Value: DESIGNED\n\n## Changelog\n- 2024-01-01 (a): created\n", updated)
+}
+
+func TestStatusUpdater_DependencyPolicy(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/p/features/checkout.md", []byte(testFeature+"\n## Feature Dependencies\n- `auth`: login\n"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/p/features/auth.md", []byte("# auth\n\n## Status\n- Value: UNDER_REVIEW\n"), 0644))
+
+	updater := NewStatusUpdater(fs)
+
+	_, err := updater.SetStatus("/p", "checkout", StatusChange{To: StatusDesigned, Policy: PolicyRefuse})
+	var readinessErr *ReadinessError
+	require.ErrorAs(t, err, &readinessErr)
+	assert.Equal(t, "auth", readinessErr.Readiness.Unmet[0].Key)
+
+	result, err := updater.SetStatus("/p", "checkout", StatusChange{To: StatusDesigned, Policy: PolicyWarn})
+	require.NoError(t, err)
+	assert.Equal(t, StatusDesigned, result.To)
+	assert.False(t, result.Readiness.Ready)
+}
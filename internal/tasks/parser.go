@@ -0,0 +1,234 @@
+package tasks
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// FileName is the workspace file that holds the tasks
+const FileName = "tasks.md"
+
+var (
+	headingRegex     = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*$`)
+	taskHeadingRegex = regexp.MustCompile(`^(T-[A-Za-z0-9][A-Za-z0-9_.-]*)\s*(?::\s*(.*))?$`)
+	taskIDRegex      = regexp.MustCompile(`T-[A-Za-z0-9][A-Za-z0-9_.-]*[A-Za-z0-9]|T-[A-Za-z0-9]`)
+	checkboxRegex    = regexp.MustCompile(`^\[([ >xX])\]`)
+	fieldRegex       = regexp.MustCompile(`^-\s+([A-Za-z][A-Za-z ]*?):\s*(.*)$`)
+)
+
+// Parser parses tasks.md
+type Parser struct {
+	fs afero.Fs
+}
+
+// NewParser creates a new tasks parser
+func NewParser(fs afero.Fs) *Parser {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &Parser{fs: fs}
+}
+
+// ParseProject parses tasks.md in the project root
+// A missing file yields an empty task list
+func (p *Parser) ParseProject(projectPath string) (*TaskList, error) {
+	path := filepath.Join(projectPath, FileName)
+
+	exists, err := afero.Exists(p.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s: %w", FileName, err)
+	}
+	if !exists {
+		return &TaskList{}, nil
+	}
+
+	content, err := afero.ReadFile(p.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+
+	return Parse(string(content)), nil
+}
+
+// Parse parses the content of tasks.md
+// Tasks are "T-<id>: <title>" headings; each task belongs to the nearest
+// enclosing non-task heading, which holds the feature key.
+func Parse(content string) *TaskList {
+	list := &TaskList{}
+	features := make(map[string]*FeatureTasks)
+
+	type heading struct {
+		level int
+		title string
+	}
+	var stack []heading
+
+	var current *Task
+	taskLevel := 0
+	inLog := false
+	inFence := false
+
+	for i, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if matches := headingRegex.FindStringSubmatch(line); matches != nil {
+			level := len(matches[1])
+			title := matches[2]
+
+			// Headings nested inside a task (e.g. #### Log)
+			if current != nil && level > taskLevel {
+				inLog = strings.EqualFold(title, "Log")
+				continue
+			}
+
+			current = nil
+			inLog = false
+
+			for len(stack) > 0 && stack[len(stack)-1].level >= level {
+				stack = stack[:len(stack)-1]
+			}
+
+			if tm := taskHeadingRegex.FindStringSubmatch(title); tm != nil {
+				featureKey := ""
+				if len(stack) > 0 && stack[len(stack)-1].level > 1 {
+					featureKey = normalizeFeatureKey(stack[len(stack)-1].title)
+				}
+
+				current = &Task{
+					ID:         tm[1],
+					Title:      strings.TrimSpace(tm[2]),
+					FeatureKey: featureKey,
+					Status:     StatusUnknown,
+					DependsOn:  []string{},
+					Log:        []string{},
+					Line:       i + 1,
+				}
+				taskLevel = level
+
+				group, exists := features[featureKey]
+				if !exists {
+					group = &FeatureTasks{FeatureKey: featureKey}
+					features[featureKey] = group
+					list.Features = append(list.Features, group)
+				}
+				group.Tasks = append(group.Tasks, current)
+				continue
+			}
+
+			stack = append(stack, heading{level: level, title: title})
+			continue
+		}
+
+		if current == nil || !strings.HasPrefix(line, "- ") {
+			continue
+		}
+
+		if inLog {
+			entry := strings.TrimSpace(strings.TrimPrefix(line, "- "))
+			if entry != "" && !strings.HasPrefix(entry, "YYYY-MM-DD") {
+				current.Log = append(current.Log, entry)
+			}
+			continue
+		}
+
+		parseField(current, line)
+	}
+
+	return list
+}
+
+// parseField fills a task field from a "- Label: value" line
+func parseField(task *Task, line string) {
+	matches := fieldRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return
+	}
+
+	value := strings.TrimSpace(matches[2])
+	if isPlaceholder(value) {
+		value = ""
+	}
+
+	switch strings.ToLower(matches[1]) {
+	case "status":
+		task.Status = ParseStatus(matches[2])
+	case "owner":
+		task.Owner = value
+	case "eta":
+		task.ETA = value
+	case "depends on":
+		task.DependsOn = ParseDependsOn(value)
+	case "links":
+		task.Links = value
+	case "description":
+		task.Description = value
+	case "deliverable":
+		task.Deliverable = value
+	}
+}
+
+// ParseStatus parses "[ ] TODO", "[>] DOING", "[x] DONE" or the bare keyword
+func ParseStatus(value string) TaskStatus {
+	value = strings.TrimSpace(value)
+
+	// The unfilled template lists every option
+	if strings.Contains(value, "/") {
+		return StatusUnknown
+	}
+
+	if matches := checkboxRegex.FindStringSubmatch(value); matches != nil {
+		switch matches[1] {
+		case " ":
+			return StatusTodo
+		case ">":
+			return StatusDoing
+		default:
+			return StatusDone
+		}
+	}
+
+	switch TaskStatus(strings.ToUpper(value)) {
+	case StatusTodo:
+		return StatusTodo
+	case StatusDoing:
+		return StatusDoing
+	case StatusDone:
+		return StatusDone
+	}
+	return StatusUnknown
+}
+
+// ParseDependsOn extracts the task IDs from a "Depends on" value
+func ParseDependsOn(value string) []string {
+	deps := []string{}
+	seen := make(map[string]bool)
+	for _, id := range taskIDRegex.FindAllString(value, -1) {
+		if !seen[id] {
+			seen[id] = true
+			deps = append(deps, id)
+		}
+	}
+	return deps
+}
+
+// normalizeFeatureKey strips markdown decoration from a feature heading
+func normalizeFeatureKey(title string) string {
+	return strings.Trim(strings.TrimSpace(title), "`*")
+}
+
+// isPlaceholder reports whether a field still holds the template value
+func isPlaceholder(value string) bool {
+	return strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">")
+}
@@ -0,0 +1,109 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleTasks = "# Tasks\n" +
+	"\n" +
+	"## checkout\n" +
+	"\n" +
+	"### T-1: Add discount table\n" +
+	"- Status: [x] DONE\n" +
+	"- Owner: alice\n" +
+	"- ETA: 2024-03-01\n" +
+	"- Depends on: none\n" +
+	"- Description: create the table\n" +
+	"- Deliverable: migration merged\n" +
+	"\n" +
+	"#### Log\n" +
+	"<!-- ARCHIE:APPEND_ONLY -->\n" +
+	"- 2024-02-20 (alice): started\n" +
+	"- 2024-02-28 (alice): merged\n" +
+	"<!-- ARCHIE:END -->\n" +
+	"\n" +
+	"### T-2: Discount API\n" +
+	"- Status: [>] DOING\n" +
+	"- Owner: bob\n" +
+	"- ETA: 2024-03-10\n" +
+	"- Depends on: T-1\n" +
+	"\n" +
+	"## `profile`\n" +
+	"\n" +
+	"### T-3: Avatar upload\n" +
+	"- Status: [ ] TODO\n" +
+	"- Depends on: T-2, T-9\n"
+
+func TestParse(t *testing.T) {
+	list := Parse(sampleTasks)
+	require.Len(t, list.Features, 2)
+
+	checkout := list.Feature("checkout")
+	require.NotNil(t, checkout)
+	require.Len(t, checkout.Tasks, 2)
+
+	first := checkout.Tasks[0]
+	assert.Equal(t, "T-1", first.ID)
+	assert.Equal(t, "Add discount table", first.Title)
+	assert.Equal(t, StatusDone, first.Status)
+	assert.Equal(t, "alice", first.Owner)
+	assert.Empty(t, first.DependsOn)
+	assert.Equal(t, []string{"2024-02-20 (alice): started", "2024-02-28 (alice): merged"}, first.Log)
+	eta, ok := first.ETADate()
+	assert.True(t, ok)
+	assert.Equal(t, 2024, eta.Year())
+
+	assert.Equal(t, StatusDoing, checkout.Tasks[1].Status)
+	assert.Equal(t, []string{"T-1"}, checkout.Tasks[1].DependsOn)
+	assert.Equal(t, Progress{Total: 2, Doing: 1, Done: 1}, checkout.Progress())
+	assert.Equal(t, 50, checkout.Progress().Percent())
+
+	profile := list.Feature("profile")
+	require.NotNil(t, profile)
+	assert.Equal(t, []string{"T-2", "T-9"}, profile.Tasks[0].DependsOn)
+}
+
+func TestParse_TemplateHasNoTasks(t *testing.T) {
+	template := "# Tasks\n\n## <feature-key>\n\n### T-<id>: <task title>\n- Status: [ ] TODO / [>] DOING / [x] DONE\n"
+	assert.Empty(t, Parse(template).All())
+}
+
+func TestValidate(t *testing.T) {
+	list := Parse(sampleTasks + "\n### T-4: Loop A\n- Status: [ ] TODO\n- Depends on: T-5\n" +
+		"\n### T-5: Loop B\n- Status: [ ]\n- Depends on: T-4\n" +
+		"\n### T-1: Duplicate\n- Status: maybe\n")
+
+	var messages []string
+	for _, issue := range list.Validate() {
+		messages = append(messages, issue.String())
+	}
+
+	assert.Equal(t, []string{
+		"tasks.md:39: T-1: status must be [ ] TODO, [>] DOING or [x] DONE",
+		"tasks.md:39: T-1: duplicate task ID (first defined on line 5)",
+		"tasks.md:27: T-3: depends on unknown task T-9",
+		"tasks.md:31: T-4: dependency cycle: T-4 → T-5 → T-4",
+	}, messages)
+}
+
+func TestCycles_Overlapping(t *testing.T) {
+	// T-1 → T-2 → T-3 → T-1 and T-1 → T-3 → T-1 share T-3 → T-1; a plain DFS finishes
+	// T-3 on the first cycle and misses the second
+	list := Parse("## checkout\n" +
+		"### T-1: A\n- Status: [ ] TODO\n- Depends on: T-2, T-3\n" +
+		"### T-2: B\n- Status: [ ] TODO\n- Depends on: T-3\n" +
+		"### T-3: C\n- Status: [ ] TODO\n- Depends on: T-1\n" +
+		"### T-4: D\n- Status: [ ] TODO\n- Depends on: T-4\n")
+
+	assert.Equal(t, [][]string{{"T-1", "T-2", "T-3"}, {"T-1", "T-3"}}, list.Cycles())
+}
+
+func TestParseProject_MissingFile(t *testing.T) {
+	list, err := NewParser(afero.NewMemMapFs()).ParseProject("/p")
+	require.NoError(t, err)
+	assert.Empty(t, list.Features)
+}
@@ -0,0 +1,143 @@
+package tasks
+
+import (
+	"strings"
+	"time"
+)
+
+// TaskStatus is the checkbox status of a task in tasks.md
+type TaskStatus string
+
+const (
+	StatusTodo    TaskStatus = "TODO"  // [ ]
+	StatusDoing   TaskStatus = "DOING" // [>]
+	StatusDone    TaskStatus = "DONE"  // [x]
+	StatusUnknown TaskStatus = "UNKNOWN"
+)
+
+// Task is a single T-<id> entry in tasks.md
+type Task struct {
+	ID          string     `json:"id" yaml:"id"`
+	Title       string     `json:"title" yaml:"title"`
+	FeatureKey  string     `json:"feature_key" yaml:"feature_key"`
+	Status      TaskStatus `json:"status" yaml:"status"`
+	Owner       string     `json:"owner" yaml:"owner"`
+	ETA         string     `json:"eta" yaml:"eta"`
+	DependsOn   []string   `json:"depends_on" yaml:"depends_on"`
+	Links       string     `json:"links" yaml:"links"`
+	Description string     `json:"description" yaml:"description"`
+	Deliverable string     `json:"deliverable" yaml:"deliverable"`
+	Log         []string   `json:"log" yaml:"log"`
+	Line        int        `json:"line" yaml:"line"` // line of the task heading (1-based)
+}
+
+// ETADate returns the ETA as a date when it is written as YYYY-MM-DD
+func (t *Task) ETADate() (time.Time, bool) {
+	eta := strings.TrimSpace(t.ETA)
+	if len(eta) < len("2006-01-02") {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("2006-01-02", eta[:len("2006-01-02")])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// FeatureTasks groups the tasks listed under one feature key
+type FeatureTasks struct {
+	FeatureKey string  `json:"feature_key" yaml:"feature_key"`
+	Tasks      []*Task `json:"tasks" yaml:"tasks"`
+}
+
+// Progress counts the tasks of a feature by status
+type Progress struct {
+	Total int `json:"total" yaml:"total"`
+	Todo  int `json:"todo" yaml:"todo"`
+	Doing int `json:"doing" yaml:"doing"`
+	Done  int `json:"done" yaml:"done"`
+}
+
+// Percent returns the share of DONE tasks (0-100)
+func (p Progress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// Progress computes the task completion of the feature
+func (f *FeatureTasks) Progress() Progress {
+	progress := Progress{Total: len(f.Tasks)}
+	for _, task := range f.Tasks {
+		switch task.Status {
+		case StatusDone:
+			progress.Done++
+		case StatusDoing:
+			progress.Doing++
+		default:
+			progress.Todo++
+		}
+	}
+	return progress
+}
+
+// TaskList is the parsed content of tasks.md
+type TaskList struct {
+	Features []*FeatureTasks `json:"features" yaml:"features"`
+}
+
+// Feature returns the tasks of a feature, or nil when it has none
+func (l *TaskList) Feature(featureKey string) *FeatureTasks {
+	for _, feature := range l.Features {
+		if feature.FeatureKey == featureKey {
+			return feature
+		}
+	}
+	return nil
+}
+
+// All returns every task in file order
+func (l *TaskList) All() []*Task {
+	var all []*Task
+	for _, feature := range l.Features {
+		all = append(all, feature.Tasks...)
+	}
+	return all
+}
+
+// ByID indexes the tasks by ID (the first task wins on duplicates)
+func (l *TaskList) ByID() map[string]*Task {
+	index := make(map[string]*Task)
+	for _, task := range l.All() {
+		if _, exists := index[task.ID]; !exists {
+			index[task.ID] = task
+		}
+	}
+	return index
+}
+
+// ProgressByFeature returns the task completion of every feature
+func (l *TaskList) ProgressByFeature() map[string]Progress {
+	result := make(map[string]Progress, len(l.Features))
+	for _, feature := range l.Features {
+		result[feature.FeatureKey] = feature.Progress()
+	}
+	return result
+}
+
+// Select returns a task list restricted to the given feature keys
+func (l *TaskList) Select(featureKeys []string) *TaskList {
+	selected := make(map[string]bool, len(featureKeys))
+	for _, key := range featureKeys {
+		selected[key] = true
+	}
+
+	result := &TaskList{}
+	for _, feature := range l.Features {
+		if selected[feature.FeatureKey] {
+			result.Features = append(result.Features, feature)
+		}
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GarrickZ2/archie/internal/graph"
+)
+
+// Issue is a problem found while validating tasks.md
+type Issue struct {
+	TaskID  string `json:"task_id" yaml:"task_id"`
+	Line    int    `json:"line" yaml:"line"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// String formats the issue as "tasks.md:line: T-x: message"
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", FileName, i.Line, i.TaskID, i.Message)
+}
+
+// Validate checks the task list and its dependency DAG
+// Reported problems: tasks without a feature, unknown status, duplicate IDs,
+// dependencies on unknown tasks or on itself, and dependency cycles.
+func (l *TaskList) Validate() []Issue {
+	var issues []Issue
+	seen := make(map[string]*Task)
+
+	for _, task := range l.All() {
+		if task.FeatureKey == "" {
+			issues = append(issues, Issue{task.ID, task.Line, "task is not listed under a feature heading"})
+		}
+
+		if task.Status == StatusUnknown {
+			issues = append(issues, Issue{task.ID, task.Line, "status must be [ ] TODO, [>] DOING or [x] DONE"})
+		}
+
+		if first, exists := seen[task.ID]; exists {
+			issues = append(issues, Issue{task.ID, task.Line, fmt.Sprintf("duplicate task ID (first defined on line %d)", first.Line)})
+			continue
+		}
+		seen[task.ID] = task
+	}
+
+	for _, task := range l.All() {
+		if seen[task.ID] != task {
+			continue
+		}
+		for _, dep := range task.DependsOn {
+			switch {
+			case dep == task.ID:
+				issues = append(issues, Issue{task.ID, task.Line, "task depends on itself"})
+			case seen[dep] == nil:
+				issues = append(issues, Issue{task.ID, task.Line, fmt.Sprintf("depends on unknown task %s", dep)})
+			}
+		}
+	}
+
+	for _, cycle := range l.Cycles() {
+		first := seen[cycle[0]]
+		issues = append(issues, Issue{
+			TaskID:  first.ID,
+			Line:    first.Line,
+			Message: fmt.Sprintf("dependency cycle: %s → %s", strings.Join(cycle, " → "), cycle[0]),
+		})
+	}
+
+	return issues
+}
+
+// Cycles returns every elementary dependency cycle between tasks (self-dependencies
+// excluded), up to graph.MaxCycles. Each cycle starts at its smallest task ID.
+func (l *TaskList) Cycles() [][]string {
+	index := l.ByID()
+	deps := make(map[string][]string, len(index))
+	for id, task := range index {
+		for _, dep := range task.DependsOn {
+			if dep != id && index[dep] != nil {
+				deps[id] = append(deps[id], dep)
+			}
+		}
+	}
+	return graph.Cycles(deps, graph.CyclicComponents(deps))
+}
@@ -0,0 +1,84 @@
+package timeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/tasks"
+)
+
+// Display renders the timeline in the terminal
+type Display struct {
+	timeline *Timeline
+}
+
+// NewDisplay creates a new timeline display
+func NewDisplay(timeline *Timeline) *Display {
+	return &Display{timeline: timeline}
+}
+
+// Show prints the schedule, the critical path and the conflicts
+func (d *Display) Show() {
+	t := d.timeline
+
+	fmt.Println()
+	fmt.Println(status.ColorBold + "  📅 Timeline" + status.ColorReset)
+	fmt.Println()
+
+	if len(t.Items) == 0 {
+		fmt.Println(status.ColorDim + "  No tasks with a YYYY-MM-DD ETA found in tasks.md" + status.ColorReset)
+		d.showUnscheduled()
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("  %-10s %-10s %-10s %-20s %s\n", "Task", "Start", "ETA", "Feature", "Title")
+	fmt.Println("  " + status.ColorDim + strings.Repeat("─", 70) + status.ColorReset)
+
+	for _, item := range t.Items {
+		color := status.ColorReset
+		switch item.Status {
+		case tasks.StatusDone:
+			color = status.ColorGreen
+		case tasks.StatusDoing:
+			color = status.ColorBlue
+		}
+
+		marker := " "
+		if item.Critical {
+			marker = status.ColorRed + "*" + status.ColorReset
+		}
+
+		fmt.Printf("  %s%s%-9s%s %-10s %-10s %-20s %s\n",
+			marker, color, item.TaskID, status.ColorReset,
+			item.Start, item.End, item.FeatureKey, item.Title)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %sCritical path:%s %s\n", status.ColorBold, status.ColorReset, strings.Join(t.CriticalPath, " → "))
+	if len(t.CriticalFeatures) > 1 {
+		fmt.Printf("  %sFeatures:%s      %s\n", status.ColorDim, status.ColorReset, strings.Join(t.CriticalFeatures, " → "))
+	}
+
+	if len(t.Conflicts) > 0 {
+		fmt.Println()
+		fmt.Println(status.ColorYellow + status.ColorBold + "  ⚠️  Schedule Conflicts" + status.ColorReset)
+		fmt.Println()
+		for _, conflict := range t.Conflicts {
+			fmt.Printf("  %s•%s %s\n", status.ColorYellow, status.ColorReset, conflict.String())
+		}
+	}
+
+	d.showUnscheduled()
+	fmt.Println()
+}
+
+// showUnscheduled lists tasks that have no usable ETA
+func (d *Display) showUnscheduled() {
+	if len(d.timeline.Unscheduled) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("  %sUnscheduled (no ETA): %s%s\n", status.ColorDim, strings.Join(d.timeline.Unscheduled, ", "), status.ColorReset)
+}
@@ -0,0 +1,108 @@
+package timeline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GarrickZ2/archie/internal/tasks"
+)
+
+var mermaidIDRegex = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// Mermaid renders the timeline as a Mermaid gantt block
+// Tasks are grouped in one section per feature; critical tasks are marked crit.
+func (t *Timeline) Mermaid() string {
+	var content strings.Builder
+
+	content.WriteString("```mermaid\n")
+	content.WriteString("gantt\n")
+	content.WriteString("    title Timeline\n")
+	content.WriteString("    dateFormat YYYY-MM-DD\n")
+
+	var sections []string
+	byFeature := make(map[string][]*Item)
+	for _, item := range t.Items {
+		if _, exists := byFeature[item.FeatureKey]; !exists {
+			sections = append(sections, item.FeatureKey)
+		}
+		byFeature[item.FeatureKey] = append(byFeature[item.FeatureKey], item)
+	}
+
+	for _, featureKey := range sections {
+		name := featureKey
+		if name == "" {
+			name = "unassigned"
+		}
+		content.WriteString(fmt.Sprintf("    section %s\n", sanitizeLabel(name)))
+
+		for _, item := range byFeature[featureKey] {
+			var tags []string
+			switch item.Status {
+			case tasks.StatusDone:
+				tags = append(tags, "done")
+			case tasks.StatusDoing:
+				tags = append(tags, "active")
+			}
+			if item.Critical {
+				tags = append(tags, "crit")
+			}
+			tags = append(tags, mermaidIDRegex.ReplaceAllString(item.TaskID, "_"), item.Start, item.End)
+
+			label := item.TaskID
+			if item.Title != "" {
+				label = fmt.Sprintf("%s %s", item.TaskID, item.Title)
+			}
+			content.WriteString(fmt.Sprintf("    %s :%s\n", sanitizeLabel(label), strings.Join(tags, ", ")))
+		}
+	}
+
+	content.WriteString("```\n")
+	return content.String()
+}
+
+// Markdown renders the timeline as an export section: gantt, critical path and conflicts
+func (t *Timeline) Markdown() string {
+	if len(t.Items) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString("## Timeline\n\n")
+	content.WriteString(t.Mermaid())
+
+	content.WriteString("\n**Critical path:** ")
+	content.WriteString(strings.Join(t.CriticalPath, " → "))
+	if len(t.CriticalFeatures) > 1 {
+		content.WriteString(fmt.Sprintf(" (%s)", strings.Join(t.CriticalFeatures, " → ")))
+	}
+	content.WriteString("\n")
+
+	if len(t.Conflicts) > 0 {
+		content.WriteString("\n**Schedule conflicts:**\n\n")
+		for _, conflict := range t.Conflicts {
+			content.WriteString("- " + conflict.String() + "\n")
+		}
+	}
+
+	if len(t.Unscheduled) > 0 {
+		content.WriteString(fmt.Sprintf("\n**Unscheduled (no ETA):** %s\n", strings.Join(t.Unscheduled, ", ")))
+	}
+
+	content.WriteString("\n")
+	return content.String()
+}
+
+// String describes the conflict in one line
+func (c Conflict) String() string {
+	msg := fmt.Sprintf("%s is due %s but its prerequisite %s is due %s", c.TaskID, c.ETA, c.Prerequisite, c.PrerequisiteETA)
+	if c.ViaFeature != "" {
+		msg += fmt.Sprintf(" (feature dependency on %s)", c.ViaFeature)
+	}
+	return msg
+}
+
+// sanitizeLabel removes characters that break Mermaid gantt syntax
+func sanitizeLabel(label string) string {
+	return strings.NewReplacer(":", " ", ";", " ", "#", "").Replace(label)
+}
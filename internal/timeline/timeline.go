@@ -0,0 +1,204 @@
+package timeline
+
+import (
+	"sort"
+	"time"
+
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/tasks"
+)
+
+const dateFormat = "2006-01-02"
+
+// Item is a scheduled task on the timeline
+type Item struct {
+	TaskID        string           `json:"task_id" yaml:"task_id"`
+	Title         string           `json:"title" yaml:"title"`
+	FeatureKey    string           `json:"feature_key" yaml:"feature_key"`
+	Status        tasks.TaskStatus `json:"status" yaml:"status"`
+	Start         string           `json:"start" yaml:"start"`
+	End           string           `json:"end" yaml:"end"` // the task ETA
+	Prerequisites []string         `json:"prerequisites" yaml:"prerequisites"`
+	Critical      bool             `json:"critical" yaml:"critical"`
+
+	start time.Time
+	end   time.Time
+}
+
+// Conflict is a task whose ETA falls before the ETA of one of its prerequisites
+type Conflict struct {
+	TaskID          string `json:"task_id" yaml:"task_id"`
+	ETA             string `json:"eta" yaml:"eta"`
+	Prerequisite    string `json:"prerequisite" yaml:"prerequisite"`
+	PrerequisiteETA string `json:"prerequisite_eta" yaml:"prerequisite_eta"`
+	ViaFeature      string `json:"via_feature,omitempty" yaml:"via_feature,omitempty"` // set when implied by a feature dependency
+}
+
+// Timeline is the schedule computed from tasks.md and the feature dependencies
+type Timeline struct {
+	Items            []*Item    `json:"items" yaml:"items"`
+	Unscheduled      []string   `json:"unscheduled" yaml:"unscheduled"` // tasks without a YYYY-MM-DD ETA
+	CriticalPath     []string   `json:"critical_path" yaml:"critical_path"`
+	CriticalFeatures []string   `json:"critical_features" yaml:"critical_features"`
+	Conflicts        []Conflict `json:"conflicts" yaml:"conflicts"`
+}
+
+// Build computes the timeline
+//
+// A task's prerequisites are its "Depends on" tasks. Tasks without explicit
+// dependencies also wait for every feature their feature depends on, i.e. for
+// the task with the latest ETA of that feature. A task starts when its last
+// prerequisite ends, or at its first Log entry when it has no prerequisites.
+func Build(taskList *tasks.TaskList, graph *status.DependencyGraph) *Timeline {
+	t := &Timeline{
+		Items:            []*Item{},
+		Unscheduled:      []string{},
+		CriticalPath:     []string{},
+		CriticalFeatures: []string{},
+		Conflicts:        []Conflict{},
+	}
+
+	index := make(map[string]*Item)
+	var all []*tasks.Task
+	for _, task := range taskList.All() {
+		if _, exists := index[task.ID]; exists {
+			continue
+		}
+		eta, ok := task.ETADate()
+		if !ok {
+			t.Unscheduled = append(t.Unscheduled, task.ID)
+			continue
+		}
+		item := &Item{
+			TaskID:        task.ID,
+			Title:         task.Title,
+			FeatureKey:    task.FeatureKey,
+			Status:        task.Status,
+			Prerequisites: []string{},
+			end:           eta,
+		}
+		index[task.ID] = item
+		all = append(all, task)
+		t.Items = append(t.Items, item)
+	}
+
+	// Finishing task of each feature (latest ETA)
+	finish := make(map[string]*Item)
+	for _, item := range t.Items {
+		if current := finish[item.FeatureKey]; current == nil || item.end.After(current.end) {
+			finish[item.FeatureKey] = item
+		}
+	}
+
+	viaFeature := make(map[string]map[string]string) // task -> prerequisite -> feature
+	for _, task := range all {
+		item := index[task.ID]
+		for _, dep := range task.DependsOn {
+			if index[dep] != nil && dep != task.ID {
+				item.Prerequisites = append(item.Prerequisites, dep)
+			}
+		}
+		if len(task.DependsOn) > 0 || graph == nil {
+			continue
+		}
+		for _, featureDep := range graph.DependsOn[task.FeatureKey] {
+			prerequisite := finish[featureDep]
+			if prerequisite == nil || prerequisite.FeatureKey == task.FeatureKey {
+				continue
+			}
+			item.Prerequisites = append(item.Prerequisites, prerequisite.TaskID)
+			if viaFeature[task.ID] == nil {
+				viaFeature[task.ID] = make(map[string]string)
+			}
+			viaFeature[task.ID][prerequisite.TaskID] = featureDep
+		}
+	}
+
+	for _, task := range all {
+		item := index[task.ID]
+
+		for _, dep := range item.Prerequisites {
+			prerequisite := index[dep]
+			if prerequisite.end.After(item.end) {
+				t.Conflicts = append(t.Conflicts, Conflict{
+					TaskID:          item.TaskID,
+					ETA:             item.end.Format(dateFormat),
+					Prerequisite:    prerequisite.TaskID,
+					PrerequisiteETA: prerequisite.end.Format(dateFormat),
+					ViaFeature:      viaFeature[task.ID][dep],
+				})
+			}
+			if prerequisite.end.After(item.start) {
+				item.start = prerequisite.end
+			}
+		}
+
+		if item.start.IsZero() {
+			item.start = firstLogDate(task)
+		}
+		if item.start.IsZero() || !item.start.Before(item.end) {
+			item.start = item.end.AddDate(0, 0, -1)
+		}
+
+		item.Start = item.start.Format(dateFormat)
+		item.End = item.end.Format(dateFormat)
+	}
+
+	sort.SliceStable(t.Items, func(i, j int) bool {
+		if !t.Items[i].start.Equal(t.Items[j].start) {
+			return t.Items[i].start.Before(t.Items[j].start)
+		}
+		return t.Items[i].end.Before(t.Items[j].end)
+	})
+
+	t.computeCriticalPath(index)
+	return t
+}
+
+// computeCriticalPath walks back from the task with the latest ETA,
+// always following the prerequisite that finishes last
+func (t *Timeline) computeCriticalPath(index map[string]*Item) {
+	var last *Item
+	for _, item := range t.Items {
+		if last == nil || item.end.After(last.end) || (item.end.Equal(last.end) && item.TaskID < last.TaskID) {
+			last = item
+		}
+	}
+
+	visited := make(map[string]bool)
+	var path []*Item
+	for current := last; current != nil && !visited[current.TaskID]; {
+		visited[current.TaskID] = true
+		path = append([]*Item{current}, path...)
+
+		var next *Item
+		for _, dep := range current.Prerequisites {
+			prerequisite := index[dep]
+			if next == nil || prerequisite.end.After(next.end) {
+				next = prerequisite
+			}
+		}
+		current = next
+	}
+
+	for _, item := range path {
+		item.Critical = true
+		t.CriticalPath = append(t.CriticalPath, item.TaskID)
+		if n := len(t.CriticalFeatures); n == 0 || t.CriticalFeatures[n-1] != item.FeatureKey {
+			t.CriticalFeatures = append(t.CriticalFeatures, item.FeatureKey)
+		}
+	}
+}
+
+// firstLogDate returns the date of the first Log entry, if any
+func firstLogDate(task *tasks.Task) time.Time {
+	for _, entry := range task.Log {
+		if len(entry) < len(dateFormat) {
+			continue
+		}
+		if date, err := time.Parse(dateFormat, entry[:len(dateFormat)]); err == nil {
+			return date
+		}
+	}
+	return time.Time{}
+}
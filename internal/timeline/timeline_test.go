@@ -0,0 +1,91 @@
+package timeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GarrickZ2/archie/internal/status"
+	"github.com/GarrickZ2/archie/internal/tasks"
+)
+
+const sampleTasks = `# Tasks
+
+## auth
+
+### T-1: Token service
+- Status: [x] DONE
+- ETA: 2024-03-05
+
+#### Log
+- 2024-03-01 (alice): started
+
+### T-2: Login API
+- Status: [>] DOING
+- ETA: 2024-03-10
+- Depends on: T-1
+
+## checkout
+
+### T-3: Cart model
+- Status: [ ] TODO
+- ETA: 2024-03-08
+
+### T-4: Pay button
+- Status: [ ] TODO
+- ETA: 2024-03-20
+- Depends on: T-3
+
+### T-5: Receipts
+- Status: [ ] TODO
+- ETA: TBD
+`
+
+func buildSample() *Timeline {
+	graph := status.BuildDependencyGraph([]status.Feature{
+		{Name: "auth", Status: status.StatusImplementing},
+		{Name: "checkout", Status: status.StatusImplementing, Dependencies: map[string]string{"auth": "login"}},
+	})
+	return Build(tasks.Parse(sampleTasks), graph)
+}
+
+func TestBuild(t *testing.T) {
+	result := buildSample()
+
+	require.Len(t, result.Items, 4)
+	assert.Equal(t, []string{"T-5"}, result.Unscheduled)
+
+	byID := make(map[string]*Item)
+	for _, item := range result.Items {
+		byID[item.TaskID] = item
+	}
+
+	// First log entry is the start when there are no prerequisites
+	assert.Equal(t, "2024-03-01", byID["T-1"].Start)
+	assert.Equal(t, "2024-03-05", byID["T-2"].Start)
+
+	// T-3 has no explicit dependencies, so it waits for the auth feature (T-2)
+	assert.Equal(t, []string{"T-2"}, byID["T-3"].Prerequisites)
+
+	assert.Equal(t, []string{"T-1", "T-2", "T-3", "T-4"}, result.CriticalPath)
+	assert.Equal(t, []string{"auth", "checkout"}, result.CriticalFeatures)
+
+	assert.Equal(t, []Conflict{{
+		TaskID:          "T-3",
+		ETA:             "2024-03-08",
+		Prerequisite:    "T-2",
+		PrerequisiteETA: "2024-03-10",
+		ViaFeature:      "auth",
+	}}, result.Conflicts)
+}
+
+func TestMermaid(t *testing.T) {
+	gantt := buildSample().Mermaid()
+
+	assert.Contains(t, gantt, "gantt\n")
+	assert.Contains(t, gantt, "    section auth\n")
+	assert.Contains(t, gantt, "    T-1 Token service :done, crit, T_1, 2024-03-01, 2024-03-05\n")
+	assert.Contains(t, gantt, "    T-2 Login API :active, crit, T_2, 2024-03-05, 2024-03-10\n")
+	assert.NotContains(t, gantt, "T-5")
+}
@@ -137,6 +137,11 @@ func ShowError(message string) {
 	fmt.Println()
 }
 
+// ShowWarning displays a warning message
+func ShowWarning(message string) {
+	fmt.Println(ColorYellow + "  ⚠️  " + message + ColorReset)
+}
+
 // ShowInfo displays an info message
 func ShowInfo(message string) {
 	fmt.Println(ColorBrightCyan + "  ℹ️  " + message + ColorReset)
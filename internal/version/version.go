@@ -0,0 +1,21 @@
+package version
+
+import "runtime/debug"
+
+// Version is the Archie release, set at build time with
+//
+//	go build -ldflags "-X github.com/GarrickZ2/archie/internal/version.Version=v1.2.0"
+//
+// When it is not set, the module version recorded by `go install` is used.
+var Version = ""
+
+// Get returns the running Archie version, or "dev" for local builds
+func Get() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
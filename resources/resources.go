@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v3"
@@ -36,6 +37,8 @@ type CommandTemplate struct {
 	Metadata map[string]interface{} `yaml:",inline"`
 	// Content 是命令的正文内容
 	Content string `yaml:"content"`
+	// Keys 是 Metadata 的键在模板文件中的顺序，格式化时按此顺序输出
+	Keys []string `yaml:"-"`
 }
 
 // LoadCommands loads all command templates from the embedded commands folder
@@ -69,6 +72,9 @@ func loadCommandTemplatesFromFS(fsys embed.FS, rootDir string) (map[string]*Comm
 		if err := yaml.Unmarshal(content, &template); err != nil {
 			return fmt.Errorf("failed to parse YAML file %s: %w", path, err)
 		}
+		if template.Keys, err = metadataKeys(content); err != nil {
+			return fmt.Errorf("failed to parse YAML file %s: %w", path, err)
+		}
 
 		// Use relative path as key (remove root directory prefix and extension)
 		relPath, err := filepath.Rel(rootDir, path)
@@ -95,6 +101,72 @@ func LoadSubAgents() (map[string]string, error) {
 	return loadTemplatesFromFS(subagentsFS, "subagents")
 }
 
+// LoadSubAgentTemplates loads all subagent templates as structured templates, so they
+// can be formatted like commands: the frontmatter becomes Metadata and the body Content
+// Returns a map where key is the template name (without extension)
+func LoadSubAgentTemplates() (map[string]*CommandTemplate, error) {
+	subAgents, err := LoadSubAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]*CommandTemplate, len(subAgents))
+	for filename, content := range subAgents {
+		template, err := parseMarkdownTemplate(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse subagent %s: %w", filename, err)
+		}
+		templates[strings.TrimSuffix(filename, filepath.Ext(filename))] = template
+	}
+	return templates, nil
+}
+
+// parseMarkdownTemplate splits a markdown file into its YAML frontmatter (Metadata)
+// and body (Content). A file without frontmatter is all Content.
+func parseMarkdownTemplate(content string) (*CommandTemplate, error) {
+	template := &CommandTemplate{Metadata: make(map[string]interface{}), Content: content}
+
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		return template, nil
+	}
+	frontmatter, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return template, nil
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &template.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	keys, err := metadataKeys([]byte(frontmatter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	template.Keys = keys
+	template.Content = body
+	return template, nil
+}
+
+// metadataKeys returns the top-level keys of a YAML mapping in file order,
+// leaving out content, which is stored apart from Metadata
+func metadataKeys(data []byte) ([]string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var keys []string
+	mapping := document.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if key := mapping.Content[i].Value; key != "content" {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
 // loadTemplatesFromFS is a helper function to load all .md files from an embedded FS
 // This is kept for backward compatibility with subagents
 func loadTemplatesFromFS(fsys embed.FS, rootDir string) (map[string]string, error) {
@@ -144,6 +216,16 @@ func GetSchemaTemplate(templateName string) (string, error) {
 	return string(content), nil
 }
 
+// GetSchemaIndex returns the content of docs/schema.md, which maps
+// workspace files to their schema templates
+func GetSchemaIndex() (string, error) {
+	content, err := fs.ReadFile(docsFS, filepath.Join("docs", "schema.md"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read schema index: %w", err)
+	}
+	return string(content), nil
+}
+
 // CopyDocsToProject copies the embedded docs folder to .archie/docs/ in the project
 func CopyDocsToProject(projectPath string, filesystem afero.Fs) error {
 	targetDir := filepath.Join(projectPath, ".archie", "docs")
@@ -197,3 +279,29 @@ func CopyDocsToProject(projectPath string, filesystem afero.Fs) error {
 
 	return nil
 }
+
+// DocFiles returns the embedded docs keyed by their path relative to .archie/docs/
+func DocFiles() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(docsFS, "docs", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel("docs", path)
+		if err != nil {
+			return err
+		}
+
+		content, err := fs.ReadFile(docsFS, path)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded file %s: %w", path, err)
+		}
+		files[relPath] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docs: %w", err)
+	}
+	return files, nil
+}
@@ -1,6 +1,7 @@
 package resources
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -68,3 +69,48 @@ func TestLoadSubAgents(t *testing.T) {
 
 	t.Logf("Loaded %d subagents", len(subAgents))
 }
+
+func TestLoadSubAgentTemplates(t *testing.T) {
+	templates, err := LoadSubAgentTemplates()
+	if err != nil {
+		t.Fatalf("LoadSubAgentTemplates() error = %v", err)
+	}
+
+	template, ok := templates["archie-api"]
+	if !ok {
+		t.Fatal("Expected subagent archie-api not found")
+	}
+	if template.Metadata["name"] != "Archie API Designer" {
+		t.Errorf("name = %v, want the frontmatter value", template.Metadata["name"])
+	}
+	if strings.HasPrefix(template.Content, "---") {
+		t.Error("Content should not include the frontmatter")
+	}
+	if !strings.Contains(template.Content, "### Mission") {
+		t.Error("Content should contain the body")
+	}
+}
+
+func TestParseMarkdownTemplate(t *testing.T) {
+	template, err := parseMarkdownTemplate("# No frontmatter\n")
+	if err != nil {
+		t.Fatalf("parseMarkdownTemplate() error = %v", err)
+	}
+	if template.Content != "# No frontmatter\n" || len(template.Metadata) != 0 {
+		t.Errorf("parseMarkdownTemplate() = %+v, want the whole file as content", template)
+	}
+
+	template, err = parseMarkdownTemplate("---\ndescription: Plan tasks\ntools: [Read]\n---\nBody\n")
+	if err != nil {
+		t.Fatalf("parseMarkdownTemplate() error = %v", err)
+	}
+	if template.Content != "Body\n" {
+		t.Errorf("Content = %q, want %q", template.Content, "Body\n")
+	}
+	if template.Metadata["description"] != "Plan tasks" {
+		t.Errorf("description = %v", template.Metadata["description"])
+	}
+	if strings.Join(template.Keys, ",") != "description,tools" {
+		t.Errorf("Keys = %v, want the frontmatter order", template.Keys)
+	}
+}